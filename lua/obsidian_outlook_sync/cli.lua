@@ -52,24 +52,128 @@ local function show_auth_window(stderr_output)
 	vim.notify('Press q or <Esc> to close this window', vim.log.levels.INFO)
 end
 
--- invoke_cli executes the outlook-md CLI and returns parsed JSON output
+-- Exit codes of the CLI for each error kind (see CLIError in pkg/schema/v1.go)
+M.exit_codes = {
+	error = 1,
+	usage = 2,
+	auth = 3,
+	network = 4,
+	throttled = 5,
+	permission = 6,
+	config = 7,
+	parse = 8,
+}
+
+-- Hints shown for each error kind
+local error_hints = {
+	auth = 'Sign-in failed or expired; run the command again to authenticate',
+	network = 'Microsoft Graph is unreachable; enable cache_fallback to use cached events offline',
+	throttled = 'Microsoft Graph is throttling requests; try again in a minute',
+	permission = 'Access denied; check that the calendar is shared with you',
+	config = 'Check the client ID and tenant ID configuration (see README)',
+	parse = 'Unexpected response from Microsoft Graph; please report this issue',
+}
+
+-- parse_cli_error extracts the JSON error object written with --error-format json
+-- @param output string: combined CLI output
+-- @param exit_code number: CLI exit code
+-- @return table: error { kind, exitCode, message, graph }
+function M.parse_cli_error(output, exit_code)
+	local lines = vim.split(output, '\n', { plain = true })
+	for i = #lines, 1, -1 do
+		local line = lines[i]
+		if line:match('^{"version":') then
+			local ok, parsed = pcall(vim.json.decode, line)
+			if ok and type(parsed) == 'table' and type(parsed.error) == 'table' then
+				return parsed.error
+			end
+		end
+	end
+
+	-- Older CLI or a crash: derive the kind from the exit code
+	local kind = 'error'
+	for name, code in pairs(M.exit_codes) do
+		if code == exit_code then
+			kind = name
+		end
+	end
+	return { kind = kind, exitCode = exit_code, message = vim.trim(output) }
+end
+
+-- format_error returns a message for a CLI error, with a hint for its kind
+-- @param cli_error table: error from parse_cli_error
+-- @return string
+function M.format_error(cli_error)
+	local msg = cli_error.message or 'unknown error'
+	local hint = error_hints[cli_error.kind]
+	if hint then
+		msg = msg .. '\n' .. hint
+	end
+	return msg
+end
+
+-- build_command returns the shell command running the outlook-md CLI. The
+-- timezone, week start and response statuses are only passed when set, so
+-- that the config file profile applies otherwise.
 -- @param command string: CLI command to run (e.g., "today")
--- @param opts table: options { cli_path, timezone, format }
--- @return table|nil: parsed JSON output (CLIOutput schema)
--- @return string|nil: error message if failed
-function M.invoke_cli(command, opts)
+-- @param opts table: options { cli_path, timezone, format, week_start, response_statuses, include_solo, hide_declined, calendars, users, delta, cache_fallback, profile, accounts }
+-- @return string
+function M.build_command(command, opts)
 	opts = opts or {}
 	local cli_path = opts.cli_path or 'outlook-md'
-	local timezone = opts.timezone or 'Local'
 	local format = opts.format or 'json'
 
 	-- Build command arguments
-	local cmd_str = string.format('%s %s --format %s --tz %s 2>&1',
+	local cmd_str = string.format('%s %s --format %s --error-format json',
 		vim.fn.shellescape(cli_path),
 		vim.fn.shellescape(command),
-		vim.fn.shellescape(format),
-		vim.fn.shellescape(timezone)
+		vim.fn.shellescape(format)
 	)
+	if opts.timezone then
+		cmd_str = cmd_str .. ' --tz ' .. vim.fn.shellescape(opts.timezone)
+	end
+	if opts.week_start then
+		cmd_str = cmd_str .. ' --week-start ' .. vim.fn.shellescape(opts.week_start)
+	end
+	if opts.response_statuses and #opts.response_statuses > 0 then
+		cmd_str = cmd_str .. ' --responses ' .. vim.fn.shellescape(table.concat(opts.response_statuses, ','))
+	end
+	if opts.include_solo then
+		cmd_str = cmd_str .. ' --include-solo'
+	end
+	if opts.hide_declined then
+		cmd_str = cmd_str .. ' --hide-declined'
+	end
+	for _, calendar in ipairs(opts.calendars or {}) do
+		cmd_str = cmd_str .. ' --calendar ' .. vim.fn.shellescape(calendar)
+	end
+	for _, user in ipairs(opts.users or {}) do
+		cmd_str = cmd_str .. ' --user ' .. vim.fn.shellescape(user)
+	end
+	if opts.delta then
+		cmd_str = cmd_str .. ' --delta'
+	end
+	if opts.cache_fallback then
+		cmd_str = cmd_str .. ' --cache-fallback'
+	end
+	if opts.profile then
+		cmd_str = cmd_str .. ' --profile ' .. vim.fn.shellescape(opts.profile)
+	end
+	for _, account in ipairs(opts.accounts or {}) do
+		cmd_str = cmd_str .. ' --account ' .. vim.fn.shellescape(account)
+	end
+	return cmd_str
+end
+
+-- invoke_cli executes the outlook-md CLI and returns parsed JSON output
+-- @param command string: CLI command to run (e.g., "today")
+-- @param opts table: options, see build_command
+-- @return table|nil: parsed JSON output (CLIOutput schema)
+-- @return string|nil: error message if failed
+-- @return table|nil: error { kind, exitCode, message, graph } if the CLI failed
+function M.invoke_cli(command, opts)
+	local cmd_str = M.build_command(command, opts)
+	cmd_str = cmd_str .. ' 2>&1'
 
 	-- Execute command and capture both stdout and stderr
 	local result = vim.fn.system(cmd_str)
@@ -91,11 +195,13 @@ function M.invoke_cli(command, opts)
 
 	-- Check for errors
 	if exit_code ~= 0 then
-		return nil, string.format("outlook-md exited with code %d: %s", exit_code, result)
+		local cli_error = M.parse_cli_error(result, exit_code)
+		return nil, M.format_error(cli_error), cli_error
 	end
 
-	-- Parse JSON output (filter out any remaining stderr messages)
-	local json_start = result:find('{')
+	-- Parse JSON output (filter out any remaining stderr messages, which may
+	-- themselves contain braces, by looking for the line starting the object)
+	local json_start = result:find('^{') or result:find('\n{')
 	if json_start then
 		result = result:sub(json_start)
 	end
@@ -33,6 +33,16 @@ local function fetch_and_sync(command, description)
 	local cli_output, err = cli.invoke_cli(command, {
 		cli_path = config.cli_path,
 		timezone = config.timezone,
+		week_start = config.week_start,
+		response_statuses = config.response_statuses,
+		include_solo = config.include_solo,
+		hide_declined = config.hide_declined,
+		calendars = config.calendars,
+		users = config.users,
+		delta = config.delta,
+		cache_fallback = config.cache_fallback,
+		profile = config.profile,
+		accounts = config.accounts,
 		format = 'json',
 	})
 
@@ -65,7 +75,13 @@ local function fetch_and_sync(command, description)
 		if deleted_count > 0 then
 			msg = msg .. string.format(' (%d deleted events retained)', deleted_count)
 		end
-		vim.notify(msg, vim.log.levels.INFO)
+		if cli_output.stale then
+			-- Graph was unreachable; the CLI served its cached copy
+			msg = msg .. string.format(' from cache fetched at %s (offline)', cli_output.fetchedAt or 'unknown time')
+			vim.notify(msg, vim.log.levels.WARN)
+		else
+			vim.notify(msg, vim.log.levels.INFO)
+		end
 	else
 		vim.notify('Failed to update buffer', vim.log.levels.ERROR)
 	end
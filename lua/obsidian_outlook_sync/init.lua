@@ -6,7 +6,21 @@ local M = {}
 -- Plugin state
 M.config = {
 	cli_path = 'outlook-md',  -- Path to outlook-md CLI binary
-	timezone = 'Local',        -- Default timezone
+	-- Timezone, first day of the week ('monday' or 'sunday') and response
+	-- statuses to include ('organizer', 'accepted', 'tentativelyAccepted',
+	-- 'notResponded', 'declined', 'none'); nil leaves them to the config file
+	-- profile, or the CLI defaults: 'Local', 'monday', { 'accepted', 'organizer' }
+	timezone = nil,
+	week_start = nil,
+	response_statuses = nil,
+	include_solo = false,      -- Include events you organized without invitees (focus blocks)
+	hide_declined = false,     -- Leave attendees who declined out of the attendee lists
+	calendars = {},            -- Calendar names or IDs to merge (empty: default calendar)
+	users = {},                -- Other users' shared/delegated calendars to read (UPNs)
+	delta = false,             -- Fetch only changes since the previous sync (Graph delta queries)
+	cache_fallback = true,     -- Show the last cached events when Graph cannot be reached
+	profile = nil,             -- outlook-md config file profile (credentials, calendars)
+	accounts = {},             -- Accounts (config file profiles) to merge into one agenda
 }
 
 -- Resolve CLI path, checking plugin's bin/ directory if not found in PATH
@@ -17,16 +17,19 @@ function M.render_event(event)
 	-- Format event header
 	local header
 	local deleted_marker = event.deleted and ' [deleted]' or ''
+	local subject = event.subject ~= '' and event.subject or '(Untitled Event)'
+	if event.account and event.account ~= '' then
+		-- Label events merged from several accounts
+		subject = '[' .. event.account .. '] ' .. subject
+	end
 
 	if event.isAllDay then
 		-- All-day event
-		local subject = event.subject ~= '' and event.subject or '(Untitled Event)'
 		header = string.format('## All Day - %s%s', subject, deleted_marker)
 	else
 		-- Timed event - parse times and format
 		local start_time = M._format_time(event.start)
 		local end_time = M._format_time(event['end'])
-		local subject = event.subject ~= '' and event.subject or '(Untitled Event)'
 		header = string.format('## %s-%s %s%s', start_time, end_time, subject, deleted_marker)
 	end
 
@@ -36,7 +39,16 @@ function M.render_event(event)
 	if event.body and event.body ~= '' then
 		table.insert(lines, '')
 		table.insert(lines, '### Agenda')
-		table.insert(lines, '- <auto> ' .. event.body)
+		-- One item per body line, reusing the body's bullets and indentation
+		for body_line in (event.body .. '\n'):gmatch('(.-)\r?\n') do
+			if body_line:match('%S') then
+				local indent, rest = body_line:match('^( *)[-*+] +(.*)$')
+				if not indent then
+					indent, rest = body_line:match('^( *)(.*)$')
+				end
+				table.insert(lines, indent .. '- <auto> ' .. rest)
+			end
+		end
 	end
 
 	-- Add combined Attendees section (organizer + invitees in single line)
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/obsidian-outlook-sync/outlook-md/internal/auth"
+	"github.com/obsidian-outlook-sync/outlook-md/internal/config"
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+// account is a Microsoft 365 account to sign in with: a config file profile
+// providing its app registration, default calendars and token cache
+type account struct {
+	name    string
+	profile config.Profile
+}
+
+// selectedAccounts returns the accounts given with --account, or else the
+// account of the selected profile
+func (o *options) selectedAccounts() ([]account, error) {
+	if len(o.accounts) == 0 {
+		return []account{{name: o.settings.Name, profile: o.settings}}, nil
+	}
+
+	var accounts []account
+	seen := map[string]bool{}
+	for _, name := range o.accounts {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		p := o.settings
+		if name != o.settings.Name {
+			var err error
+			if p, err = o.file.Profile(name); err != nil {
+				return nil, withKind(schema.ErrorKindConfig, err)
+			}
+		}
+		accounts = append(accounts, account{name: name, profile: p})
+	}
+	return accounts, nil
+}
+
+// credentialEnv are the environment variables that override the
+// credentials of an account
+var credentialEnv = []string{
+	"OUTLOOK_MD_ACCESS_TOKEN",
+	"OUTLOOK_MD_CLIENT_ID",
+	"OUTLOOK_MD_TENANT_ID",
+	"OUTLOOK_MD_CLIENT_SECRET",
+	"OUTLOOK_MD_CLIENT_CERTIFICATE",
+}
+
+// checkCredentialEnv rejects credential environment variables when several
+// accounts are selected, as they would replace the credentials of each
+func checkCredentialEnv(accounts []account) error {
+	if len(accounts) < 2 {
+		return nil
+	}
+	for _, name := range credentialEnv {
+		if os.Getenv(name) != "" {
+			return withKind(schema.ErrorKindConfig, fmt.Errorf("%s would apply to every account; unset it to merge several accounts", name))
+		}
+	}
+	return nil
+}
+
+// isDefault reports whether this is the default profile's account, whose
+// state is kept where it was before accounts were introduced
+func (a account) isDefault() bool {
+	return a.name == "" || a.name == config.DefaultProfile
+}
+
+// tokenCachePath returns the token cache of the account under dir:
+// token.json for the default account and tokens/<name>.json otherwise
+func (a account) tokenCachePath(dir string) string {
+	if a.isDefault() {
+		return filepath.Join(dir, "token.json")
+	}
+	return filepath.Join(dir, "tokens", a.name+".json")
+}
+
+// deltaDir returns the directory of the account's delta state under dir
+func (a account) deltaDir(dir string) string {
+	if a.isDefault() {
+		return filepath.Join(dir, "delta")
+	}
+	return filepath.Join(dir, "delta", a.name)
+}
+
+// cloud returns the Microsoft cloud the account signs in to and reads from
+func (a account) cloud() (auth.Cloud, error) {
+	s := config.LoadCloudSettings(a.profile)
+	cloud, err := auth.ResolveCloud(s.Cloud, s.AuthorityHost, s.GraphHost)
+	if err != nil {
+		return auth.Cloud{}, withKind(schema.ErrorKindConfig, err)
+	}
+	return cloud, nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/obsidian-outlook-sync/outlook-md/internal/config"
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+// TestSelectedAccounts verifies that --account selects config file profiles
+func TestSelectedAccounts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	content := "[profiles.contoso]\nclient_id = \"contoso-client\"\n\n[profiles.fabrikam]\nclient_id = \"fabrikam-client\"\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	file, err := config.LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings, err := file.Profile("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := &options{file: file, settings: settings}
+	accounts, err := opts.selectedAccounts()
+	if err != nil || len(accounts) != 1 || accounts[0].name != config.DefaultProfile {
+		t.Fatalf("Expected the default account, got %+v, %v", accounts, err)
+	}
+
+	opts.accounts = stringList{"contoso", "fabrikam", "contoso"}
+	accounts, err = opts.selectedAccounts()
+	if err != nil || len(accounts) != 2 {
+		t.Fatalf("Expected two accounts, got %+v, %v", accounts, err)
+	}
+	if accounts[0].profile.ClientID != "contoso-client" || accounts[1].profile.ClientID != "fabrikam-client" {
+		t.Errorf("Unexpected account profiles: %+v", accounts)
+	}
+
+	opts.accounts = stringList{"missing"}
+	if _, err := opts.selectedAccounts(); err == nil {
+		t.Error("Expected error for an account without a profile")
+	}
+}
+
+// TestCredentialEnvWithSeveralAccounts verifies that credentials from the
+// environment are refused when they would apply to several accounts
+func TestCredentialEnvWithSeveralAccounts(t *testing.T) {
+	for _, name := range credentialEnv {
+		t.Setenv(name, "")
+	}
+	one := []account{{name: "contoso"}}
+	two := []account{{name: "contoso"}, {name: "fabrikam"}}
+
+	if err := checkCredentialEnv(two); err != nil {
+		t.Errorf("Expected no error without credential variables, got %v", err)
+	}
+
+	for _, name := range credentialEnv {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(name, "value")
+			if err := checkCredentialEnv(one); err != nil {
+				t.Errorf("Expected %s to apply to a single account, got %v", name, err)
+			}
+			if err := checkCredentialEnv(two); err == nil || classifyError(err) != schema.ErrorKindConfig {
+				t.Errorf("Expected config error for %s with two accounts, got %v", name, err)
+			}
+		})
+	}
+}
+
+// TestAccountPaths verifies that each account keeps its own tokens and delta state
+func TestAccountPaths(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+		delta string
+	}{
+		{"", "state/token.json", "state/delta"},
+		{config.DefaultProfile, "state/token.json", "state/delta"},
+		{"contoso", "state/tokens/contoso.json", "state/delta/contoso"},
+	}
+
+	for _, tt := range tests {
+		acct := account{name: tt.name}
+		if got := acct.tokenCachePath("state"); got != tt.token {
+			t.Errorf("tokenCachePath(%q) = %s, want %s", tt.name, got, tt.token)
+		}
+		if got := acct.deltaDir("state"); got != tt.delta {
+			t.Errorf("deltaDir(%q) = %s, want %s", tt.name, got, tt.delta)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/obsidian-outlook-sync/outlook-md/internal/auth"
+	"github.com/obsidian-outlook-sync/outlook-md/internal/calendar"
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+// handleAuthCommand manages the sign-in of an account:
+// auth login | logout [--revoke] | status | token
+func handleAuthCommand(opts *options, args []string) error {
+	fs := newCommandFlagSet("auth", opts)
+	revoke := fs.Bool("revoke", false, "Also revoke every sign-in session of the account (logout only)")
+	rest, err := parseCommandArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 1 {
+		return usageErrorf("usage: outlook-md auth login|logout|status|token [--account <name>]")
+	}
+	if *revoke && rest[0] != "logout" {
+		return usageErrorf("--revoke is only supported by auth logout")
+	}
+
+	accounts, err := opts.selectedAccounts()
+	if err != nil {
+		return err
+	}
+	if len(accounts) > 1 {
+		return usageErrorf("auth manages one account at a time")
+	}
+	acct := accounts[0]
+
+	switch rest[0] {
+	case "login":
+		session, err := newSession(acct)
+		if err != nil {
+			return withKind(schema.ErrorKindAuth, err)
+		}
+		if err := session.Login(); err != nil {
+			return withKind(schema.ErrorKindAuth, err)
+		}
+		return printAuthStatus(os.Stdout, acct, session.Cache(), time.Now())
+	case "logout":
+		return authLogout(opts, acct, *revoke)
+	case "status":
+		cache, err := newTokenCache(acct)
+		if err != nil {
+			return err
+		}
+		return printAuthStatus(os.Stdout, acct, cache, time.Now())
+	case "token":
+		tokens, err := newTokenSource(acct)
+		if err != nil {
+			return withKind(schema.ErrorKindAuth, err)
+		}
+		token, err := tokens.Token()
+		if err != nil {
+			return withKind(schema.ErrorKindAuth, fmt.Errorf("authentication failed: %w", err))
+		}
+		fmt.Println(token)
+		return nil
+	default:
+		return usageErrorf("unknown auth subcommand: %s (supported: login, logout, status, token)", rest[0])
+	}
+}
+
+// authLogout deletes the account's cached token, first revoking its
+// sessions with Graph if requested
+func authLogout(opts *options, acct account, revoke bool) error {
+	cache, err := newTokenCache(acct)
+	if err != nil {
+		return err
+	}
+
+	if revoke {
+		if _, err := cache.Load(); err != nil {
+			return withKind(schema.ErrorKindAuth, fmt.Errorf("%s is not signed in, nothing to revoke", acct.name))
+		}
+		retry, err := opts.retryPolicy()
+		if err != nil {
+			return err
+		}
+		session, err := newSession(acct)
+		if err != nil {
+			return err
+		}
+		cloud, err := acct.cloud()
+		if err != nil {
+			return err
+		}
+		// Revoking must not sign in again if the cached token is unusable
+		session.DisableSignIn()
+		client := calendar.NewGraphClientWithBaseURL(session, cloud.GraphURL(), calendar.WithRetryPolicy(retry))
+		if err := client.RevokeSignInSessions(context.Background()); err != nil {
+			return fmt.Errorf("failed to revoke sign-in sessions (the token cache was kept): %w", err)
+		}
+		fmt.Fprintln(os.Stderr, "Revoked all sign-in sessions")
+	}
+
+	if err := cache.Delete(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Signed out of %s\n", acct.name)
+	return nil
+}
+
+// printAuthStatus describes the cached sign-in of an account
+func printAuthStatus(w io.Writer, acct account, cache *auth.TokenCache, now time.Time) error {
+	info, err := cache.Info()
+	if os.IsNotExist(err) {
+		return withKind(schema.ErrorKindAuth, fmt.Errorf("%s is not signed in (run 'outlook-md auth login')", acct.name))
+	}
+	if err != nil {
+		return withKind(schema.ErrorKindAuth, err)
+	}
+
+	fmt.Fprintf(w, "Account:        %s\n", acct.name)
+	user := info.Username
+	if info.Name != "" {
+		user = fmt.Sprintf("%s <%s>", info.Name, info.Username)
+	}
+	fmt.Fprintf(w, "Signed in as:   %s\n", valueOrUnknown(user))
+	fmt.Fprintf(w, "Tenant:         %s\n", valueOrUnknown(info.TenantID))
+	fmt.Fprintf(w, "Scopes:         %s\n", valueOrUnknown(strings.Join(info.Scopes, " ")))
+
+	switch {
+	case info.Expiry.IsZero():
+		fmt.Fprintf(w, "Access token:   no expiry\n")
+	case info.Expiry.After(now):
+		fmt.Fprintf(w, "Access token:   expires %s (in %s)\n", info.Expiry.Local().Format(time.RFC1123), formatAge(info.Expiry.Sub(now)))
+	default:
+		fmt.Fprintf(w, "Access token:   expired %s (%s ago)\n", info.Expiry.Local().Format(time.RFC1123), formatAge(now.Sub(info.Expiry)))
+	}
+
+	switch {
+	case !info.HasRefreshToken:
+		fmt.Fprintf(w, "Refresh token:  none\n")
+	case info.RefreshTokenIssuedAt.IsZero():
+		fmt.Fprintf(w, "Refresh token:  issued at an unknown time\n")
+	default:
+		fmt.Fprintf(w, "Refresh token:  issued %s (%s ago)\n", info.RefreshTokenIssuedAt.Local().Format(time.RFC1123), formatAge(now.Sub(info.RefreshTokenIssuedAt)))
+	}
+
+	fmt.Fprintf(w, "Token cache:    %s\n", cache.Path())
+	return nil
+}
+
+// valueOrUnknown returns s, or "unknown" if it is empty (e.g. a token
+// cached before id_tokens were requested)
+func valueOrUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
+
+// formatAge formats a duration in its largest whole unit (e.g. 3d, 5h, 12m)
+func formatAge(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	default:
+		return fmt.Sprintf("%ds", int(d/time.Second))
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/obsidian-outlook-sync/outlook-md/internal/auth"
+	"github.com/obsidian-outlook-sync/outlook-md/internal/config"
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+	"golang.org/x/oauth2"
+)
+
+// TestPrintAuthStatus verifies the status of a cached sign-in
+func TestPrintAuthStatus(t *testing.T) {
+	cache := auth.NewTokenCache(filepath.Join(t.TempDir(), "token.json"))
+	acct := account{name: "work"}
+	now := time.Now()
+
+	var buf bytes.Buffer
+	if err := printAuthStatus(&buf, acct, cache, now); err == nil || classifyError(err) != schema.ErrorKindAuth {
+		t.Errorf("Expected auth error when not signed in, got %v", err)
+	}
+
+	token := &oauth2.Token{AccessToken: "access", RefreshToken: "refresh", Expiry: now.Add(90 * time.Minute)}
+	if err := cache.Save(token.WithExtra(map[string]interface{}{"scope": "Calendars.Read offline_access"})); err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	if err := printAuthStatus(&buf, acct, cache, now); err != nil {
+		t.Fatalf("printAuthStatus failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"Account:        work\n",
+		"Signed in as:   unknown\n",
+		"Scopes:         Calendars.Read offline_access\n",
+		"(in 1h)\n",
+		"Refresh token:  issued ",
+		"Token cache:    " + cache.Path() + "\n",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected status to contain %q, got:\n%s", want, buf.String())
+		}
+	}
+}
+
+// TestFormatAge verifies durations are shown in their largest unit
+func TestFormatAge(t *testing.T) {
+	tests := map[time.Duration]string{
+		45 * time.Second:              "45s",
+		59 * time.Minute:              "59m",
+		25 * time.Hour:                "1d",
+		14*24*time.Hour + 3*time.Hour: "14d",
+	}
+	for d, want := range tests {
+		if got := formatAge(d); got != want {
+			t.Errorf("formatAge(%s) = %s, want %s", d, got, want)
+		}
+	}
+}
+
+// TestAppOnlyTokenSource verifies that client_credentials profiles use
+// app-only credentials and need --user
+func TestAppOnlyTokenSource(t *testing.T) {
+	for _, env := range []string{"OUTLOOK_MD_ACCESS_TOKEN", "OUTLOOK_MD_CLIENT_ID", "OUTLOOK_MD_TENANT_ID", "OUTLOOK_MD_AUTH_FLOW", "OUTLOOK_MD_CLIENT_SECRET", "OUTLOOK_MD_CLIENT_CERTIFICATE"} {
+		t.Setenv(env, "")
+	}
+	acct := account{name: "daemon", profile: config.Profile{Name: "daemon", ClientID: "client", TenantID: "tenant", AuthFlow: config.AuthFlowClientCredentials, ClientSecret: "s3cret"}}
+
+	tokens, err := newTokenSource(acct)
+	if err != nil {
+		t.Fatalf("newTokenSource failed: %v", err)
+	}
+	if _, ok := tokens.(*auth.ClientCredentials); !ok {
+		t.Errorf("Expected client credentials, got %T", tokens)
+	}
+	if _, err := newSession(acct); err == nil {
+		t.Error("Expected no interactive session for an app-only account")
+	}
+
+	for _, tt := range []struct {
+		names, users []string
+		ok           bool
+	}{
+		{nil, []string{"alice@contoso.com"}, true},
+		{nil, nil, false},
+		{[]string{"Work"}, []string{"alice@contoso.com"}, false},
+	} {
+		if err := checkAppOnlySelection(tt.names, tt.users); (err == nil) != tt.ok {
+			t.Errorf("checkAppOnlySelection(%q, %q) = %v", tt.names, tt.users, err)
+		}
+	}
+}
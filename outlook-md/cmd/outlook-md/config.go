@@ -0,0 +1,208 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/obsidian-outlook-sync/outlook-md/internal/auth"
+	"github.com/obsidian-outlook-sync/outlook-md/internal/calendar"
+	"github.com/obsidian-outlook-sync/outlook-md/internal/config"
+	"github.com/obsidian-outlook-sync/outlook-md/internal/output"
+	"github.com/obsidian-outlook-sync/outlook-md/internal/timezone"
+	"github.com/obsidian-outlook-sync/outlook-md/internal/window"
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+// settingValidators check the values given to config set the way the
+// commands reading them do, so that an invalid value is never saved
+var settingValidators = map[string]func(raw string) error{
+	"timezone": func(raw string) error {
+		_, err := timezone.Resolve(raw)
+		return err
+	},
+	"format": func(raw string) error {
+		if !output.IsSupported(raw) {
+			return fmt.Errorf("unsupported format %q (supported: %s)", raw, strings.Join(output.SupportedFormats, ", "))
+		}
+		return nil
+	},
+	"week_start": func(raw string) error {
+		_, err := window.ParseWeekday(raw)
+		return err
+	},
+	"responses": func(raw string) error {
+		_, err := calendar.ParseResponseStatuses(raw)
+		return err
+	},
+	"auth_flow": config.CheckAuthFlow,
+	"cloud": func(raw string) error {
+		_, err := auth.ResolveCloud(raw, "", "")
+		return err
+	},
+	"authority_host": func(raw string) error {
+		_, err := auth.ResolveCloud("", raw, "")
+		return err
+	},
+	"graph_host": func(raw string) error {
+		_, err := auth.ResolveCloud("", "", raw)
+		return err
+	},
+}
+
+// profileArg finds the last --profile among args, which are parsed only
+// after the profile has supplied the flag defaults, or returns fallback
+func profileArg(args []string, fallback string) string {
+	profile := fallback
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			break
+		}
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		name := strings.TrimLeft(arg, "-")
+		if value, ok := strings.CutPrefix(name, "profile="); ok {
+			profile = value
+		} else if name == "profile" && i+1 < len(args) {
+			profile = args[i+1]
+			i++
+		}
+	}
+	return profile
+}
+
+// loadProfile reads the named profile from the config file and uses its
+// settings for every option not given on the command line before the
+// command; options given after the command override them when parsed
+func (o *options) loadProfile(name string) error {
+	path, err := config.Path()
+	if err != nil {
+		return withKind(schema.ErrorKindConfig, err)
+	}
+	file, err := config.LoadFile(path)
+	if err != nil {
+		return withKind(schema.ErrorKindConfig, err)
+	}
+	p, err := file.Profile(name)
+	if err != nil {
+		return withKind(schema.ErrorKindConfig, err)
+	}
+	o.file = file
+	o.applyProfile(p, setFlags(flag.CommandLine))
+	return nil
+}
+
+// applyProfile copies the settings of p into the options, skipping those
+// whose flag is in set. Calendars and users are kept apart (see selection).
+func (o *options) applyProfile(p config.Profile, set map[string]bool) {
+	o.settings = p
+	o.profile = p.Name
+	if p.Timezone != "" && !set["tz"] {
+		o.timezone = p.Timezone
+	}
+	if p.Format != "" && !set["format"] {
+		o.format = p.Format
+	}
+	if p.WeekStart != "" && !set["week-start"] {
+		o.weekStart = p.WeekStart
+	}
+	if p.Responses != "" && !set["responses"] {
+		o.responses = p.Responses
+	}
+	if p.IncludeSolo != nil && !set["include-solo"] {
+		o.includeSolo = *p.IncludeSolo
+	}
+	if p.HideDeclined != nil && !set["hide-declined"] {
+		o.hideDeclined = *p.HideDeclined
+	}
+}
+
+// selection returns the calendars and users to read from an account: those
+// given with --calendar and --user, or else those of the account's profile
+func (o *options) selection(p config.Profile) (calendars, users []string) {
+	if len(o.calendars) > 0 || len(o.users) > 0 {
+		return o.calendars, o.users
+	}
+	return p.Calendars, p.Users
+}
+
+// setFlags returns the names of the flags set on fs
+func setFlags(fs *flag.FlagSet) map[string]bool {
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+	return set
+}
+
+// handleConfigCommand shows or edits the config file:
+// config path | list | get <key> | set <key> <value>
+func handleConfigCommand(opts *options, args []string) error {
+	fs := newCommandFlagSet("config", opts)
+	rest, err := parseCommandArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) == 0 {
+		return usageErrorf("missing config subcommand (path, list, get or set)")
+	}
+
+	path, err := config.Path()
+	if err != nil {
+		return withKind(schema.ErrorKindConfig, err)
+	}
+	subcommand, rest := rest[0], rest[1:]
+	if subcommand == "path" {
+		if len(rest) > 0 {
+			return usageErrorf("usage: outlook-md config path")
+		}
+		fmt.Println(path)
+		return nil
+	}
+
+	file, err := config.LoadFile(path)
+	if err != nil {
+		return withKind(schema.ErrorKindConfig, err)
+	}
+
+	switch subcommand {
+	case "list":
+		if len(rest) > 0 {
+			return usageErrorf("usage: outlook-md config list")
+		}
+		for _, line := range file.List() {
+			fmt.Println(line)
+		}
+		return nil
+	case "get":
+		if len(rest) != 1 {
+			return usageErrorf("usage: outlook-md config get [--profile <name>] <key>")
+		}
+		value, ok := file.Get(opts.profile, rest[0])
+		if !ok {
+			return fmt.Errorf("%s is not set in profile %q", rest[0], file.ProfileName(opts.profile))
+		}
+		fmt.Println(value)
+		return nil
+	case "set":
+		if len(rest) != 2 {
+			return usageErrorf("usage: outlook-md config set [--profile <name>] <key> <value>")
+		}
+		if validate, ok := settingValidators[rest[0]]; ok {
+			if err := validate(rest[1]); err != nil {
+				return usageErrorf("invalid %s: %w", rest[0], err)
+			}
+		}
+		if err := file.Set(opts.profile, rest[0], rest[1]); err != nil {
+			return usageErrorf("%w", err)
+		}
+		if err := file.Save(); err != nil {
+			return withKind(schema.ErrorKindConfig, err)
+		}
+		return nil
+	default:
+		return usageErrorf("unknown config subcommand: %s (supported: path, list, get, set)", subcommand)
+	}
+}
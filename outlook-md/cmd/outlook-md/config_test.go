@@ -0,0 +1,102 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/obsidian-outlook-sync/outlook-md/internal/config"
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+// TestProfileArg verifies that --profile is found before flags are parsed
+func TestProfileArg(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"none", []string{"--tz", "UTC"}, "env"},
+		{"separate value", []string{"--format", "json", "--profile", "work"}, "work"},
+		{"single dash", []string{"-profile", "work"}, "work"},
+		{"equals", []string{"--profile=home"}, "home"},
+		{"last wins", []string{"--profile", "work", "--profile=home"}, "home"},
+		{"after terminator", []string{"--", "--profile", "work"}, "env"},
+		{"value looks like flag", []string{"--profile", "--profile"}, "--profile"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := profileArg(tt.args, "env"); got != tt.want {
+				t.Errorf("profileArg(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestApplyProfile verifies that flags given on the command line win over the profile
+func TestApplyProfile(t *testing.T) {
+	solo := true
+	p := config.Profile{
+		Name:         "work",
+		Timezone:     "Europe/London",
+		Format:       "markdown",
+		IncludeSolo:  &solo,
+		HideDeclined: &solo,
+		Calendars:    []string{"Calendar", "Project X"},
+	}
+
+	opts := &options{format: "ics", timezone: "Local"}
+	opts.applyProfile(p, map[string]bool{"format": true})
+	if opts.timezone != "Europe/London" || opts.format != "ics" || !opts.includeSolo || !opts.hideDeclined || opts.profile != "work" {
+		t.Errorf("Unexpected options after applying profile: %+v", opts)
+	}
+
+	calendars, users := opts.selection(p)
+	if !reflect.DeepEqual(calendars, p.Calendars) || users != nil {
+		t.Errorf("Expected profile calendars, got %q, %q", calendars, users)
+	}
+
+	opts.users = stringList{"manager@example.com"}
+	calendars, users = opts.selection(p)
+	if calendars != nil || !reflect.DeepEqual(users, []string{"manager@example.com"}) {
+		t.Errorf("Expected --user to replace the profile selection, got %q, %q", calendars, users)
+	}
+}
+
+// TestConfigSetValidates verifies that config set rejects values the
+// commands would fail on, without writing the file
+func TestConfigSetValidates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	t.Setenv("OUTLOOK_MD_CONFIG", path)
+
+	tests := []struct {
+		key, value string
+	}{
+		{"timezone", "Mars/Base"},
+		{"format", "xml"},
+		{"week_start", "funday"},
+		{"responses", "maybe"},
+		{"auth_flow", "foo"},
+		{"cloud", "nowhere"},
+		{"graph_host", "http://graph.example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			err := handleConfigCommand(&options{}, []string{"set", tt.key, tt.value})
+			if err == nil || classifyError(err) != schema.ErrorKindUsage {
+				t.Errorf("Expected usage error for %s = %q, got %v", tt.key, tt.value, err)
+			}
+			if _, err := os.Stat(path); !os.IsNotExist(err) {
+				t.Errorf("Expected no config file to be written, got %v", err)
+			}
+		})
+	}
+
+	for _, setting := range [][2]string{{"timezone", "UTC"}, {"week_start", "sunday"}, {"responses", "accepted,tentativelyAccepted"}} {
+		if err := handleConfigCommand(&options{}, []string{"set", setting[0], setting[1]}); err != nil {
+			t.Errorf("config set %s %s failed: %v", setting[0], setting[1], err)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"time"
+
+	"github.com/obsidian-outlook-sync/outlook-md/internal/window"
+)
+
+// parseWeekStart parses --week-start, the first day of week expressions
+func parseWeekStart(opts *options) (time.Weekday, error) {
+	weekStart, err := window.ParseWeekday(opts.weekStart)
+	if err != nil {
+		return 0, usageErrorf("invalid --week-start: %w", err)
+	}
+	return weekStart, nil
+}
+
+// parseDateExpr parses a date expression using the configured first day of the week
+func parseDateExpr(opts *options, expr string) (window.Expr, error) {
+	weekStart, err := parseWeekStart(opts)
+	if err != nil {
+		return nil, err
+	}
+	return window.Parse(expr, weekStart)
+}
+
+// parseRange parses --from and --to into a resolver for the window from the
+// start of from to the end of to; resolving fails if to ends before from starts
+func parseRange(opts *options, from, to string) (func(now time.Time) (window.Window, error), error) {
+	weekStart, err := parseWeekStart(opts)
+	if err != nil {
+		return nil, err
+	}
+	fromExpr, err := window.Parse(from, weekStart)
+	if err != nil {
+		return nil, usageErrorf("invalid --from: %w", err)
+	}
+	toExpr, err := window.Parse(to, weekStart)
+	if err != nil {
+		return nil, usageErrorf("invalid --to: %w", err)
+	}
+
+	return func(now time.Time) (window.Window, error) {
+		fromWindow, toWindow := fromExpr(now), toExpr(now)
+		if !fromWindow.Start.Before(toWindow.End) {
+			return window.Window{}, usageErrorf("--to (%s) is before --from (%s)", to, from)
+		}
+		return window.Window{Start: fromWindow.Start, End: toWindow.End}, nil
+	}, nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+func TestParseRange(t *testing.T) {
+	// Wednesday
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name      string
+		weekStart string
+		from, to  string
+		start     time.Time
+		end       time.Time
+	}{
+		{"single day", "monday", "2026-10-01", "2026-10-01", day(1), day(2)},
+		{"absolute dates", "monday", "2026-10-01", "2026-10-14", day(1), day(15)},
+		{"relative days", "monday", "yesterday", "+3d", day(13), day(18)},
+		{"week to date", "monday", "week", "today", day(12), day(15)},
+		{"week start", "sunday", "week", "week", day(11), day(18)},
+		{"weekday", "monday", "today", "next friday", day(14), day(17)},
+		{"overlapping", "monday", "week", "tomorrow", day(12), day(16)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolve, err := parseRange(&options{weekStart: tt.weekStart}, tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("parseRange failed: %v", err)
+			}
+			w, err := resolve(now)
+			if err != nil {
+				t.Fatalf("resolve failed: %v", err)
+			}
+			if !w.Start.Equal(tt.start) || !w.End.Equal(tt.end) {
+				t.Errorf("Expected [%v, %v), got [%v, %v)", tt.start, tt.end, w.Start, w.End)
+			}
+		})
+	}
+}
+
+func TestParseRangeErrors(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		weekStart string
+		from, to  string
+		flag      string // Flag the error should name
+	}{
+		{"invalid from", "monday", "someday", "today", "invalid --from"},
+		{"invalid to", "monday", "today", "2026-13-01", "invalid --to"},
+		{"invalid week start", "funday", "week", "week", "invalid --week-start"},
+		{"reversed dates", "monday", "2026-10-14", "2026-10-01", "--to"},
+		{"reversed relative", "monday", "+3d", "today", "--to"},
+		{"to before from", "monday", "next week", "friday", "--to"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolve, err := parseRange(&options{weekStart: tt.weekStart}, tt.from, tt.to)
+			if err == nil {
+				_, err = resolve(now)
+			}
+			if err == nil || classifyError(err) != schema.ErrorKindUsage {
+				t.Fatalf("Expected usage error, got %v", err)
+			}
+			if !strings.HasPrefix(err.Error(), tt.flag) {
+				t.Errorf("Expected error starting with %q, got %q", tt.flag, err)
+			}
+		})
+	}
+}
+
+// TestRangeCommandUsage verifies that invalid range arguments are usage
+// errors reported before signing in
+func TestRangeCommandUsage(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"no from", nil},
+		{"only to", []string{"--to", "today"}},
+		{"extra argument", []string{"--from", "today", "tomorrow"}},
+		{"invalid from", []string{"--from", "someday"}},
+		{"invalid to", []string{"--from", "today", "--to", "someday"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &options{format: "json", timezone: "UTC", weekStart: "monday", maxAttempts: 1}
+			err := handleRangeCommand(opts, tt.args)
+			if err == nil || classifyError(err) != schema.ErrorKindUsage {
+				t.Errorf("Expected usage error, got %v", err)
+			}
+		})
+	}
+}
+
+// TestDateCommandUsage verifies that date commands report an invalid
+// --week-start rather than an unknown command
+func TestDateCommandUsage(t *testing.T) {
+	opts := &options{format: "json", timezone: "UTC", weekStart: "funday", maxAttempts: 1}
+	err := handleDateCommand(opts, "week", nil)
+	if err == nil || !strings.HasPrefix(err.Error(), "invalid --week-start") {
+		t.Errorf("Expected invalid --week-start, got %v", err)
+	}
+
+	opts.weekStart = "monday"
+	err = handleDateCommand(opts, "someday", nil)
+	if err == nil || classifyError(err) != schema.ErrorKindUsage || !strings.HasPrefix(err.Error(), "unknown command") {
+		t.Errorf("Expected unknown command, got %v", err)
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/obsidian-outlook-sync/outlook-md/internal/calendar"
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+// exitCodes maps error kinds to process exit codes (see schema.ErrorKind*)
+var exitCodes = map[string]int{
+	schema.ErrorKindGeneral:    1,
+	schema.ErrorKindUsage:      2,
+	schema.ErrorKindAuth:       3,
+	schema.ErrorKindNetwork:    4,
+	schema.ErrorKindThrottled:  5,
+	schema.ErrorKindPermission: 6,
+	schema.ErrorKindConfig:     7,
+	schema.ErrorKindParse:      8,
+}
+
+// kindError attaches an error kind to an error
+type kindError struct {
+	kind string
+	err  error
+}
+
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+func (e *kindError) Unwrap() error {
+	return e.err
+}
+
+// withKind marks err with kind unless it already carries a kind
+func withKind(kind string, err error) error {
+	var ke *kindError
+	if err == nil || errors.As(err, &ke) {
+		return err
+	}
+	return &kindError{kind: kind, err: err}
+}
+
+// usageErrorf formats an error caused by an invalid command, flag or argument
+func usageErrorf(format string, args ...interface{}) error {
+	return &kindError{kind: schema.ErrorKindUsage, err: fmt.Errorf(format, args...)}
+}
+
+// classifyError determines the kind of a failure from the errors it wraps
+func classifyError(err error) string {
+	var urlErr *url.Error
+	isNetwork := errors.As(err, &urlErr) || errors.Is(err, context.DeadlineExceeded)
+
+	var ke *kindError
+	if errors.As(err, &ke) {
+		// Signing in fails when offline too; report that as a network failure
+		if ke.kind == schema.ErrorKindAuth && isNetwork {
+			return schema.ErrorKindNetwork
+		}
+		return ke.kind
+	}
+
+	var graphErr *calendar.GraphError
+	if errors.As(err, &graphErr) {
+		switch {
+		case graphErr.StatusCode == 401:
+			return schema.ErrorKindAuth
+		case graphErr.StatusCode == 403:
+			return schema.ErrorKindPermission
+		case graphErr.StatusCode == 429:
+			return schema.ErrorKindThrottled
+		case graphErr.StatusCode >= 500:
+			return schema.ErrorKindNetwork
+		default:
+			return schema.ErrorKindGeneral
+		}
+	}
+
+	if isNetwork {
+		return schema.ErrorKindNetwork
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var timeErr *time.ParseError
+	if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) || errors.As(err, &timeErr) {
+		return schema.ErrorKindParse
+	}
+
+	return schema.ErrorKindGeneral
+}
+
+// reportError writes err to w as text or, with format "json", as a
+// schema.CLIError line, and returns the exit code for it
+func reportError(w io.Writer, format string, err error) int {
+	kind := classifyError(err)
+	code := exitCodes[kind]
+
+	if format != "json" {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return code
+	}
+
+	out := schema.CLIError{
+		Version: 1,
+		Error: schema.ErrorDetail{
+			Kind:     kind,
+			ExitCode: code,
+			Message:  err.Error(),
+		},
+	}
+	var graphErr *calendar.GraphError
+	if errors.As(err, &graphErr) {
+		out.Error.Graph = &schema.GraphErrorDetail{
+			Status:     graphErr.StatusCode,
+			Code:       graphErr.Code,
+			Message:    graphErr.Message,
+			RequestID:  graphErr.RequestID,
+			RetryAfter: int(graphErr.RetryAfter.Round(time.Second) / time.Second),
+		}
+	}
+
+	data, marshalErr := json.Marshal(out)
+	if marshalErr != nil {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return code
+	}
+	fmt.Fprintln(w, string(data))
+	return code
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/obsidian-outlook-sync/outlook-md/internal/calendar"
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+// TestClassifyError verifies the exit code contract for each kind of failure
+func TestClassifyError(t *testing.T) {
+	netErr := &url.Error{Op: "Get", URL: "https://graph.microsoft.com", Err: errors.New("no route to host")}
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+		code int
+	}{
+		{"plain error", errors.New("boom"), schema.ErrorKindGeneral, 1},
+		{"usage", usageErrorf("unknown command: %s", "someday"), schema.ErrorKindUsage, 2},
+		{"rejected token", &calendar.GraphError{StatusCode: 401}, schema.ErrorKindAuth, 3},
+		{"sign-in failed", withKind(schema.ErrorKindAuth, errors.New("device code expired")), schema.ErrorKindAuth, 3},
+		{"sign-in offline", withKind(schema.ErrorKindAuth, fmt.Errorf("authentication failed: %w", netErr)), schema.ErrorKindNetwork, 4},
+		{"network", fmt.Errorf("failed to fetch calendar events: %w", netErr), schema.ErrorKindNetwork, 4},
+		{"service unavailable", &calendar.GraphError{StatusCode: 503}, schema.ErrorKindNetwork, 4},
+		{"throttled", fmt.Errorf("failed: %w", &calendar.GraphError{StatusCode: 429}), schema.ErrorKindThrottled, 5},
+		{"access denied", &calendar.GraphError{StatusCode: 403}, schema.ErrorKindPermission, 6},
+		{
+			"config wrapped by auth",
+			withKind(schema.ErrorKindAuth, fmt.Errorf("authentication failed: %w", withKind(schema.ErrorKindConfig, errors.New("client ID not found")))),
+			schema.ErrorKindConfig,
+			7,
+		},
+		{"bad response", fmt.Errorf("failed to decode response: %w", &json.SyntaxError{}), schema.ErrorKindParse, 8},
+		{"bad time", fmt.Errorf("failed to parse events: %w", &time.ParseError{}), schema.ErrorKindParse, 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind := classifyError(tt.err)
+			if kind != tt.want {
+				t.Errorf("classifyError() = %q, want %q", kind, tt.want)
+			}
+			if exitCodes[kind] != tt.code {
+				t.Errorf("exit code = %d, want %d", exitCodes[kind], tt.code)
+			}
+		})
+	}
+}
+
+// TestReportError verifies the text and JSON error output
+func TestReportError(t *testing.T) {
+	graphErr := &calendar.GraphError{
+		StatusCode: 429,
+		Code:       "TooManyRequests",
+		Message:    "Please retry again later.",
+		RequestID:  "req-1",
+		RetryAfter: 30 * time.Second,
+	}
+	err := fmt.Errorf("failed to fetch calendar events: %w", graphErr)
+
+	var text bytes.Buffer
+	if code := reportError(&text, "text", err); code != 5 {
+		t.Errorf("Expected exit code 5, got %d", code)
+	}
+	if !strings.HasPrefix(text.String(), "Error: failed to fetch calendar events") {
+		t.Errorf("Unexpected text output: %q", text.String())
+	}
+
+	var out bytes.Buffer
+	if code := reportError(&out, "json", err); code != 5 {
+		t.Errorf("Expected exit code 5, got %d", code)
+	}
+	var cliErr schema.CLIError
+	if err := json.Unmarshal(out.Bytes(), &cliErr); err != nil {
+		t.Fatalf("Expected JSON error, got %q: %v", out.String(), err)
+	}
+	if cliErr.Error.Kind != schema.ErrorKindThrottled || cliErr.Error.ExitCode != 5 {
+		t.Errorf("Unexpected error detail: %+v", cliErr.Error)
+	}
+	g := cliErr.Error.Graph
+	if g == nil || g.Status != 429 || g.Code != "TooManyRequests" || g.RequestID != "req-1" || g.RetryAfter != 30 {
+		t.Errorf("Unexpected Graph detail: %+v", g)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/obsidian-outlook-sync/outlook-md/internal/auth"
+	"github.com/obsidian-outlook-sync/outlook-md/internal/calendar"
+	"github.com/obsidian-outlook-sync/outlook-md/internal/window"
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+// handleJoinCommand opens the join link of the current or next online
+// meeting of today or tomorrow
+func handleJoinCommand(opts *options, args []string) error {
+	fs := newCommandFlagSet("join", opts)
+	openerFlag := fs.String("opener", opts.settings.Opener, "Command opening the join link (default: the system's URL handler)")
+	printFlag := fs.Bool("print", false, "Print the join link instead of opening it")
+	rest, err := parseCommandArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return usageErrorf("unexpected arguments to join: %s", strings.Join(rest, " "))
+	}
+
+	today, err := parseDateExpr(opts, "today")
+	if err != nil {
+		return err
+	}
+	tomorrow, err := parseDateExpr(opts, "tomorrow")
+	if err != nil {
+		return err
+	}
+	cliOutput, err := fetchWindow(opts, func(now time.Time) (window.Window, error) {
+		return window.Window{Start: today(now).Start, End: tomorrow(now).End}, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	event, ok := calendar.MeetingToJoin(cliOutput.Events, time.Now())
+	if !ok {
+		return fmt.Errorf("no current or upcoming meeting with a join link today or tomorrow")
+	}
+
+	fmt.Fprintf(os.Stderr, "Joining %s (%s %s-%s)\n", event.Subject, event.Start.Format("Mon"), event.Start.Format("15:04"), event.End.Format("15:04"))
+	if *printFlag {
+		fmt.Println(event.JoinURL)
+		return nil
+	}
+	return openJoinURL(*openerFlag, event.JoinURL)
+}
+
+// openJoinURL opens the link with opener, or the system's URL handler if
+// opener is empty. It waits for the opener, so a failing one is reported as a
+// configuration error; it should hand the link over and exit, as xdg-open and
+// a browser that is already running do.
+func openJoinURL(opener, url string) error {
+	cmd := openerCommand(opener, url)
+	if cmd == nil {
+		if err := auth.OpenBrowser(url); err != nil {
+			return fmt.Errorf("failed to open %s: %w", url, err)
+		}
+		return nil
+	}
+
+	cmd.Stdout, cmd.Stderr = os.Stderr, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return withKind(schema.ErrorKindConfig, fmt.Errorf("failed to run opener %q: %w", opener, err))
+	}
+	return nil
+}
+
+// openerCommand builds the command running opener (a program and its
+// arguments, e.g. "firefox --new-window") with the link appended; nil if
+// opener is empty
+func openerCommand(opener, url string) *exec.Cmd {
+	fields := strings.Fields(opener)
+	if len(fields) == 0 {
+		return nil
+	}
+	return exec.Command(fields[0], append(fields[1:], url)...)
+}
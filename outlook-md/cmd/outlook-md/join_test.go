@@ -0,0 +1,32 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+// TestOpenerCommand verifies that the opener's arguments precede the link
+func TestOpenerCommand(t *testing.T) {
+	url := "https://meet.google.com/abc-defg-hij"
+
+	if cmd := openerCommand("  ", url); cmd != nil {
+		t.Errorf("Expected no command for an empty opener, got %v", cmd.Args)
+	}
+
+	cmd := openerCommand("firefox --new-window", url)
+	if want := []string{"firefox", "--new-window", url}; cmd == nil || !reflect.DeepEqual(cmd.Args, want) {
+		t.Errorf("Expected %q, got %v", want, cmd)
+	}
+
+	if err := openJoinURL("/nonexistent/opener", url); err == nil || classifyError(err) != schema.ErrorKindConfig {
+		t.Errorf("Expected config error for a missing opener, got %v", err)
+	}
+	if err := openJoinURL("false", url); err == nil || classifyError(err) != schema.ErrorKindConfig {
+		t.Errorf("Expected config error for a failing opener, got %v", err)
+	}
+	if err := openJoinURL("true --new-window", url); err != nil {
+		t.Errorf("Expected no error for a successful opener, got %v", err)
+	}
+}
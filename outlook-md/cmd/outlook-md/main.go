@@ -6,33 +6,137 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/obsidian-outlook-sync/outlook-md/internal/auth"
+	"github.com/obsidian-outlook-sync/outlook-md/internal/cache"
 	"github.com/obsidian-outlook-sync/outlook-md/internal/calendar"
 	"github.com/obsidian-outlook-sync/outlook-md/internal/config"
+	"github.com/obsidian-outlook-sync/outlook-md/internal/note"
 	"github.com/obsidian-outlook-sync/outlook-md/internal/output"
+	"github.com/obsidian-outlook-sync/outlook-md/internal/timezone"
+	"github.com/obsidian-outlook-sync/outlook-md/internal/window"
 	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
 )
 
 const (
 	version = "0.1.0"
+
+	// deltaStateMaxAge is how long unused delta state is kept
+	deltaStateMaxAge = 30 * 24 * time.Hour
+
+	// cacheMaxAge is how long cached events are kept for --offline
+	cacheMaxAge = 30 * 24 * time.Hour
 )
 
 func main() {
-	if err := run(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+	opts := &options{
+		format:      "json",
+		timezone:    "Local",
+		weekStart:   "monday",
+		responses:   strings.Join(calendar.DefaultFilter().ResponseStatuses, ","),
+		maxAttempts: calendar.DefaultRetryPolicy().MaxAttempts,
+		retryBudget: calendar.DefaultRetryPolicy().Budget,
+		errorFormat: "text",
+		profile:     os.Getenv("OUTLOOK_MD_PROFILE"),
+	}
+	if err := run(opts); err != nil {
+		os.Exit(reportError(os.Stderr, opts.errorFormat, err))
+	}
+}
+
+// options holds the flags shared by every command
+type options struct {
+	format        string
+	timezone      string
+	weekStart     string
+	responses     string
+	includeSolo   bool
+	hideDeclined  bool
+	calendars     stringList
+	users         stringList
+	accounts      stringList
+	delta         bool
+	offline       bool
+	cacheFallback bool
+	maxAttempts   int
+	retryBudget   time.Duration
+	errorFormat   string
+	profile       string
+
+	// settings is the config file profile selected with --profile
+	settings config.Profile
+	file     *config.File
+}
+
+// stringList is a repeatable string flag
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// bindFlags registers the shared flags on fs, defaulting to the current values
+// so that flags given after the command override those given before it
+func (o *options) bindFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.format, "format", o.format, "Output format (json, markdown or ics)")
+	fs.StringVar(&o.timezone, "tz", o.timezone, "Timezone for calendar view (e.g., America/New_York, UTC)")
+	fs.StringVar(&o.weekStart, "week-start", o.weekStart, "First day of the week (monday or sunday)")
+	fs.StringVar(&o.responses, "responses", o.responses, "Comma-separated response statuses to include (or \"all\")")
+	fs.BoolVar(&o.includeSolo, "include-solo", o.includeSolo, "Include events you organized without invitees")
+	fs.BoolVar(&o.hideDeclined, "hide-declined", o.hideDeclined, "Leave out attendees who declined")
+	fs.Var(&o.calendars, "calendar", "Calendar name or ID to read (repeatable, default: your default calendar)")
+	fs.Var(&o.users, "user", "Read the default calendar of another user shared or delegated to you (repeatable)")
+	fs.Var(&o.accounts, "account", "Account (config file profile) to sign in with (repeatable to merge accounts)")
+	fs.BoolVar(&o.delta, "delta", o.delta, "Fetch only changes since the previous --delta run and mark them in the output")
+	fs.BoolVar(&o.offline, "offline", o.offline, "Serve the last cached events without contacting Graph")
+	fs.BoolVar(&o.cacheFallback, "cache-fallback", o.cacheFallback, "Serve the last cached events if Graph cannot be reached")
+	fs.IntVar(&o.maxAttempts, "max-attempts", o.maxAttempts, "Attempts per Graph request before giving up on throttling or transient errors")
+	fs.DurationVar(&o.retryBudget, "retry-budget", o.retryBudget, "Total time a fetch may spend retrying Graph requests, across all its pages (e.g. 30s, 2m)")
+	fs.StringVar(&o.errorFormat, "error-format", o.errorFormat, "Format of errors written to stderr (text or json)")
+	fs.StringVar(&o.profile, "profile", o.profile, "Config file profile to use (default: default_profile or \"default\")")
+}
+
+// filter builds the event filter from --responses, --include-solo and --hide-declined
+func (o *options) filter() (calendar.Filter, error) {
+	statuses, err := calendar.ParseResponseStatuses(o.responses)
+	if err != nil {
+		return calendar.Filter{}, usageErrorf("invalid --responses: %w", err)
+	}
+	return calendar.Filter{ResponseStatuses: statuses, IncludeSolo: o.includeSolo, HideDeclined: o.hideDeclined}, nil
+}
+
+// retryPolicy builds the Graph retry policy from --max-attempts and --retry-budget
+func (o *options) retryPolicy() (calendar.RetryPolicy, error) {
+	if o.maxAttempts < 1 {
+		return calendar.RetryPolicy{}, usageErrorf("invalid --max-attempts: must be at least 1")
 	}
+	if o.retryBudget < 0 {
+		return calendar.RetryPolicy{}, usageErrorf("invalid --retry-budget: must not be negative")
+	}
+
+	policy := calendar.DefaultRetryPolicy()
+	policy.MaxAttempts = o.maxAttempts
+	policy.Budget = o.retryBudget
+	policy.OnRetry = func(err error, wait time.Duration) {
+		fmt.Fprintf(os.Stderr, "Warning: %v; retrying in %s\n", err, wait.Round(time.Millisecond))
+	}
+	return policy, nil
 }
 
-func run() error {
+func run(opts *options) error {
 	// Define global flags
+	opts.bindFlags(flag.CommandLine)
 	var (
-		formatFlag   = flag.String("format", "json", "Output format (json only for now)")
-		timezoneFlag = flag.String("tz", "Local", "Timezone for calendar view (e.g., America/New_York, UTC)")
-		versionFlag  = flag.Bool("version", false, "Print version and exit")
-		helpFlag     = flag.Bool("help", false, "Show help")
+		versionFlag = flag.Bool("version", false, "Print version and exit")
+		helpFlag    = flag.Bool("help", false, "Show help")
 	)
 
 	flag.Parse()
@@ -51,17 +155,34 @@ func run() error {
 
 	// Get command
 	command := flag.Arg(0)
+	args := flag.Args()[1:]
+
+	// The config command manages the file, so it must work even if the file
+	// is invalid; every other command takes its defaults from the profile
+	if command == "config" {
+		return handleConfigCommand(opts, args)
+	}
+	if err := opts.loadProfile(profileArg(args, opts.profile)); err != nil {
+		return err
+	}
 
 	// Route to command handler
 	switch command {
-	case "today":
-		return handleTodayCommand(*formatFlag, *timezoneFlag)
-	case "tomorrow":
-		return handleTomorrowCommand(*formatFlag, *timezoneFlag)
-	case "week":
-		return handleWeekCommand(*formatFlag, *timezoneFlag)
+	case "range":
+		return handleRangeCommand(opts, args)
+	case "sync":
+		return handleSyncCommand(opts, args)
+	case "calendars":
+		return handleCalendarsCommand(opts, args)
+	case "auth":
+		return handleAuthCommand(opts, args)
+	case "join":
+		return handleJoinCommand(opts, args)
+	case "series":
+		return handleSeriesCommand(opts, args)
 	default:
-		return fmt.Errorf("unknown command: %s", command)
+		// Any other command is a date expression (today, next-week, +3d, ...)
+		return handleDateCommand(opts, command, args)
 	}
 }
 
@@ -71,206 +192,563 @@ func printUsage() {
 	fmt.Println("Commands:")
 	fmt.Println("  today      Fetch today's calendar events (00:00-24:00)")
 	fmt.Println("  tomorrow   Fetch tomorrow's calendar events (00:00-24:00)")
-	fmt.Println("  week       Fetch this week's calendar events (Mon-Sun)")
+	fmt.Println("  week       Fetch this week's calendar events (Mon-Sun, see --week-start)")
+	fmt.Println("  range      Fetch events between --from and --to (inclusive)")
+	fmt.Println("  sync       Merge events into the agenda region of --file [date] (default: today)")
+	fmt.Println("  calendars  List your calendars (id, name, color, owner, canEdit)")
+	fmt.Println("  config     Manage the config file: path, list, get <key>, set <key> <value>")
+	fmt.Println("  auth       Manage sign-in: login, logout [--revoke], status, token")
+	fmt.Println("  join       Open the join link of the current or next online meeting")
+	fmt.Println("  series     List the occurrences of the recurring series <event-id> belongs to")
+	fmt.Println("  <date>     Fetch events for any date expression (see below)")
+	fmt.Println("")
+	fmt.Println("Date expressions:")
+	fmt.Println("  today, tomorrow, yesterday")
+	fmt.Println("  week, last-week, next-week, month, last-month, next-month")
+	fmt.Println("  +3d, -2d            Day relative to today")
+	fmt.Println("  friday, next friday, last friday")
+	fmt.Println("  2026-10-01          Calendar date")
 	fmt.Println("")
 	fmt.Println("Options:")
-	fmt.Println("  --format <format>   Output format (default: json)")
+	fmt.Println("  --format <format>   Output format: json, markdown or ics (default: json)")
 	fmt.Println("  --tz <timezone>     Timezone for calendar view (default: Local)")
+	fmt.Println("  --week-start <day>  First day of the week: monday or sunday (default: monday)")
+	fmt.Println("  --responses <list>  Response statuses to include: organizer, accepted,")
+	fmt.Println("                      tentativelyAccepted, notResponded, declined, none or all")
+	fmt.Println("                      (default: accepted,organizer)")
+	fmt.Println("  --include-solo      Include events you organized without invitees")
+	fmt.Println("  --hide-declined     Leave attendees who declined out of the attendee lists")
+	fmt.Println("  --calendar <name>   Calendar name or ID to read; repeat to merge several")
+	fmt.Println("                      (default: your default calendar)")
+	fmt.Println("  --user <upn>        Read another user's calendar shared or delegated to you;")
+	fmt.Println("                      repeat for several users")
+	fmt.Println("  --account <name>    Account to sign in with, named after its config file")
+	fmt.Println("                      profile; repeat to merge the agendas of several accounts")
+	fmt.Println("                      (default: the --profile account)")
+	fmt.Println("  --delta             Fetch only changes since the previous --delta run of the")
+	fmt.Println("                      same window; changed events are marked in the output")
+	fmt.Println("  --offline           Serve the events cached by the last successful fetch of")
+	fmt.Println("                      the same window without contacting Graph")
+	fmt.Println("  --cache-fallback    Serve cached events if fetching from Graph fails")
+	fmt.Println("  --max-attempts <n>  Attempts per Graph request when throttled or on transient")
+	fmt.Println("                      errors, honoring Retry-After (default: 4)")
+	fmt.Println("  --retry-budget <d>  Total time a fetch may spend retrying (default: 60s)")
+	fmt.Println("  --error-format <f>  Errors on stderr as text or as a JSON object (default: text)")
+	fmt.Println("  --profile <name>    Config file profile to use (default: the file's")
+	fmt.Println("                      default_profile, else \"default\"; env: OUTLOOK_MD_PROFILE)")
+	fmt.Println("  --from <date>       Start of range (range and series only, series default: -90d)")
+	fmt.Println("  --to <date>         End of range, inclusive (range and series only,")
+	fmt.Println("                      default: --from, series default: +90d)")
+	fmt.Println("  --file <path>       Markdown note to update (sync command only)")
+	fmt.Println("  --revoke            Also sign out of every session in Microsoft 365")
+	fmt.Println("                      (auth logout only)")
+	fmt.Println("  --opener <command>  Command opening the join link, e.g. \"firefox --new-window\"")
+	fmt.Println("                      (join only, default: xdg-open or open)")
+	fmt.Println("  --print             Print the join link instead of opening it (join only)")
 	fmt.Println("  --version           Print version and exit")
 	fmt.Println("  --help              Show this help message")
 	fmt.Println("")
+	fmt.Println("Exit codes:")
+	fmt.Println("  0 success, 1 other error, 2 usage, 3 authentication, 4 network,")
+	fmt.Println("  5 throttled, 6 permission denied, 7 configuration, 8 unexpected response")
+	fmt.Println("")
 	fmt.Println("Examples:")
 	fmt.Println("  outlook-md today --format json --tz America/New_York")
 	fmt.Println("  outlook-md tomorrow --tz UTC")
 	fmt.Println("  outlook-md week --tz Europe/London")
+	fmt.Println("  outlook-md next friday --tz Europe/Paris")
+	fmt.Println("  outlook-md range --from 2026-10-01 --to 2026-10-14")
+	fmt.Println("  outlook-md range --from -3d --to today")
+	fmt.Println("  outlook-md today --format markdown >> daily.md")
+	fmt.Println("  outlook-md week --format ics > week.ics")
+	fmt.Println("  outlook-md today --responses accepted,organizer,tentativelyAccepted --include-solo")
+	fmt.Println("  outlook-md calendars --format markdown")
+	fmt.Println("  outlook-md week --calendar Calendar --calendar \"Project X\"")
+	fmt.Println("  outlook-md tomorrow --user manager@example.com --format markdown")
+	fmt.Println("  outlook-md today --delta")
+	fmt.Println("  outlook-md today --cache-fallback --format markdown")
+	fmt.Println("  outlook-md today --error-format json")
+	fmt.Println("  outlook-md config set --profile work timezone Europe/London")
+	fmt.Println("  outlook-md week --profile work")
+	fmt.Println("  outlook-md today --account contoso --account fabrikam --format markdown")
+	fmt.Println("  outlook-md auth status --account contoso")
+	fmt.Println("  curl -H \"Authorization: Bearer $(outlook-md auth token)\" https://graph.microsoft.com/v1.0/me")
+	fmt.Println("  outlook-md sync --file ~/vault/daily/2026-10-01.md 2026-10-01")
+	fmt.Println("  outlook-md join --opener \"firefox --new-window\"")
+	fmt.Println("  outlook-md series AAMkAGI2... --from 2026-01-01 --to 2026-12-31")
 }
 
-func handleTodayCommand(format string, timezone string) error {
-	// Validate format
-	if format != "json" {
-		return fmt.Errorf("unsupported format: %s (only 'json' is supported)", format)
+// newCommandFlagSet creates a flag set for a command that also accepts the shared flags
+func newCommandFlagSet(command string, opts *options) *flag.FlagSet {
+	fs := flag.NewFlagSet(command, flag.ContinueOnError)
+	opts.bindFlags(fs)
+	return fs
+}
+
+// parseCommandArgs parses flags interspersed with positional arguments
+// and returns the positional arguments in order
+func parseCommandArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, withKind(schema.ErrorKindUsage, err)
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
 	}
+}
 
-	// Load timezone
-	loc, err := time.LoadLocation(timezone)
+// handleDateCommand fetches events for a single date expression such as
+// "today", "next-week" or "next friday"
+func handleDateCommand(opts *options, command string, args []string) error {
+	fs := newCommandFlagSet(command, opts)
+	rest, err := parseCommandArgs(fs, args)
 	if err != nil {
-		return fmt.Errorf("invalid timezone: %w", err)
+		return err
+	}
+
+	if err := validateFormat(opts.format); err != nil {
+		return err
 	}
 
-	// Get actual timezone name for API
-	actualTimezone := getActualTimezone(timezone, loc)
+	// Multi-word expressions may be passed quoted or as separate arguments
+	expr := strings.Join(append([]string{command}, rest...), " ")
+	resolve, err := parseDateExpr(opts, expr)
+	if err != nil {
+		if classifyError(err) == schema.ErrorKindUsage {
+			return err // Invalid --week-start
+		}
+		return usageErrorf("unknown command: %s", expr)
+	}
 
-	// Calculate today's window (00:00 to 24:00 in specified timezone)
-	now := time.Now().In(loc)
-	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
-	endOfDay := startOfDay.Add(24 * time.Hour)
+	cliOutput, err := fetchWindow(opts, func(now time.Time) (window.Window, error) {
+		return resolve(now), nil
+	})
+	if err != nil {
+		return err
+	}
 
-	return fetchAndOutputEvents(format, actualTimezone, startOfDay, endOfDay)
+	return writeOutput(opts.format, cliOutput)
 }
 
-// getAccessToken retrieves an OAuth2 access token
-// Priority: 1) Environment variable, 2) Cached token, 3) Device-code flow
-func getAccessToken() (string, error) {
-	// First, check for env var (for testing and manual override)
-	envToken := os.Getenv("OUTLOOK_MD_ACCESS_TOKEN")
-	if envToken != "" {
-		return envToken, nil
+// handleSyncCommand merges events for a date expression (default: today)
+// into the AGENDA_START/AGENDA_END region of a markdown file on disk
+func handleSyncCommand(opts *options, args []string) error {
+	fs := newCommandFlagSet("sync", opts)
+	fileFlag := fs.String("file", "", "Markdown note containing AGENDA_START/AGENDA_END markers")
+	rest, err := parseCommandArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if *fileFlag == "" {
+		return usageErrorf("sync requires --file")
 	}
 
-	// Load configuration
-	cfg, err := config.Load()
+	expr := "today"
+	if len(rest) > 0 {
+		expr = strings.Join(rest, " ")
+	}
+	resolve, err := parseDateExpr(opts, expr)
 	if err != nil {
-		return "", fmt.Errorf("failed to load configuration: %w", err)
+		return err
 	}
 
-	// Determine cache file location
-	homeDir, err := os.UserHomeDir()
+	// Check the note before fetching so a typo doesn't trigger authentication
+	if _, err := os.Stat(*fileFlag); err != nil {
+		return fmt.Errorf("failed to open note: %w", err)
+	}
+
+	cliOutput, err := fetchWindow(opts, func(now time.Time) (window.Window, error) {
+		return resolve(now), nil
+	})
 	if err != nil {
-		return "", fmt.Errorf("failed to get home directory: %w", err)
+		return err
 	}
-	cacheDir := filepath.Join(homeDir, ".outlook-md")
-	cacheFile := filepath.Join(cacheDir, "token.json")
 
-	// Create cache directory if it doesn't exist
-	if err := os.MkdirAll(cacheDir, 0700); err != nil {
-		return "", fmt.Errorf("failed to create cache directory: %w", err)
+	result, err := note.SyncFile(*fileFlag, cliOutput.Events)
+	if err != nil {
+		return fmt.Errorf("failed to sync note: %w", err)
+	}
+
+	msg := fmt.Sprintf("Synced %d events", result.Active)
+	if cliOutput.Stale {
+		msg = fmt.Sprintf("Synced %d cached events", result.Active)
+	}
+	if result.Deleted > 0 {
+		msg += fmt.Sprintf(" (%d deleted events retained)", result.Deleted)
 	}
+	fmt.Fprintf(os.Stderr, "%s into %s\n", msg, *fileFlag)
 
-	// Create token cache
-	tokenCache := auth.NewTokenCache(cacheFile)
+	return nil
+}
 
-	// Try to load cached token
-	token, err := tokenCache.Load()
-	if err == nil {
-		// Token loaded successfully, check if it needs refresh
-		tokenSource := auth.NewTokenSource(token, cfg.ClientID, cfg.TenantID, tokenCache)
-		refreshedToken, err := tokenSource.Token()
-		if err != nil {
-			// Token refresh failed, need to re-authenticate
-			fmt.Fprintf(os.Stderr, "Warning: Failed to refresh token: %v\n", err)
-			fmt.Fprintf(os.Stderr, "Re-authenticating...\n\n")
-		} else {
-			// Token is valid or was refreshed successfully
-			return refreshedToken.AccessToken, nil
-		}
-	} else if !os.IsNotExist(err) {
-		// Unexpected error loading cache (not just "file not found")
-		return "", fmt.Errorf("failed to load token cache: %w", err)
+// handleRangeCommand fetches events from the start of --from to the end of --to
+func handleRangeCommand(opts *options, args []string) error {
+	fs := newCommandFlagSet("range", opts)
+	fromFlag := fs.String("from", "", "Start of range (date expression)")
+	toFlag := fs.String("to", "", "End of range, inclusive (date expression, default: --from)")
+	rest, err := parseCommandArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return usageErrorf("unexpected arguments to range: %s", strings.Join(rest, " "))
+	}
+	if *fromFlag == "" {
+		return usageErrorf("range requires --from")
+	}
+	if *toFlag == "" {
+		*toFlag = *fromFlag
 	}
 
-	// No cached token or refresh failed - initiate device code flow
-	authenticator := auth.NewDeviceCodeAuthenticator(cfg.ClientID, cfg.TenantID)
-	ctx := context.Background()
-	token, err = authenticator.Authenticate(ctx)
+	if err := validateFormat(opts.format); err != nil {
+		return err
+	}
+	resolve, err := parseRange(opts, *fromFlag, *toFlag)
 	if err != nil {
-		return "", fmt.Errorf("device code authentication failed: %w", err)
+		return err
 	}
 
-	// Save token to cache
-	if err := tokenCache.Save(token); err != nil {
-		// Log warning but don't fail - we have a valid token
-		fmt.Fprintf(os.Stderr, "Warning: Failed to save token to cache: %v\n", err)
+	cliOutput, err := fetchWindow(opts, resolve)
+	if err != nil {
+		return err
 	}
 
-	return token.AccessToken, nil
+	return writeOutput(opts.format, cliOutput)
 }
 
-// handleTomorrowCommand fetches tomorrow's calendar events
-func handleTomorrowCommand(format string, timezone string) error {
-	// Validate format
-	if format != "json" {
-		return fmt.Errorf("unsupported format: %s (only 'json' is supported)", format)
+// handleCalendarsCommand lists the user's calendars
+func handleCalendarsCommand(opts *options, args []string) error {
+	fs := newCommandFlagSet("calendars", opts)
+	rest, err := parseCommandArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return usageErrorf("unexpected arguments to calendars: %s", strings.Join(rest, " "))
+	}
+	if opts.format != "json" && opts.format != "markdown" {
+		return usageErrorf("unsupported format for calendars: %s (supported: json, markdown)", opts.format)
+	}
+	if opts.offline {
+		return usageErrorf("calendars is not available with --offline")
+	}
+
+	retry, err := opts.retryPolicy()
+	if err != nil {
+		return err
+	}
+	accounts, err := opts.selectedAccounts()
+	if err != nil {
+		return err
+	}
+	if len(accounts) > 1 {
+		return usageErrorf("calendars lists one account at a time")
 	}
 
-	// Load timezone
-	loc, err := time.LoadLocation(timezone)
+	tokens, err := newTokenSource(accounts[0])
+	if err != nil {
+		return withKind(schema.ErrorKindAuth, fmt.Errorf("authentication failed: %w", err))
+	}
+	if _, ok := tokens.(*auth.ClientCredentials); ok {
+		return usageErrorf("calendars lists the signed-in user's calendars and is not available with app-only auth")
+	}
+	client, err := newGraphClient(accounts[0], tokens, calendar.WithRetryPolicy(retry))
+	if err != nil {
+		return err
+	}
+	calendars, err := client.ListCalendars(context.Background())
 	if err != nil {
-		return fmt.Errorf("invalid timezone: %w", err)
+		return fmt.Errorf("failed to list calendars: %w", err)
 	}
 
-	// Get actual timezone name for API
-	actualTimezone := getActualTimezone(timezone, loc)
+	list := &schema.CalendarList{Version: 1, Calendars: calendars}
+	if err := output.FormatCalendars(opts.format, list, os.Stdout); err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	return nil
+}
 
-	// Calculate tomorrow's window (00:00 to 24:00 in specified timezone)
-	now := time.Now().In(loc)
-	tomorrow := now.Add(24 * time.Hour)
-	startOfTomorrow := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 0, 0, 0, 0, loc)
-	endOfTomorrow := startOfTomorrow.Add(24 * time.Hour)
+// printWarning reports a failure that leaves details out of the output
+func printWarning(err error) {
+	fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+}
 
-	return fetchAndOutputEvents(format, actualTimezone, startOfTomorrow, endOfTomorrow)
+// validateFormat checks that format is a supported output format
+func validateFormat(format string) error {
+	if !output.IsSupported(format) {
+		return usageErrorf("unsupported format: %s (supported: %s)", format, strings.Join(output.SupportedFormats, ", "))
+	}
+	return nil
 }
 
-// handleWeekCommand fetches this week's calendar events (Monday-Sunday)
-func handleWeekCommand(format string, timezone string) error {
-	// Validate format
-	if format != "json" {
-		return fmt.Errorf("unsupported format: %s (only 'json' is supported)", format)
+// fetchWindow resolves a window in the --tz location and fetches its events
+func fetchWindow(opts *options, resolve func(now time.Time) (window.Window, error)) (*schema.CLIOutput, error) {
+	filter, err := opts.filter()
+	if err != nil {
+		return nil, err
+	}
+	retry, err := opts.retryPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	actualTimezone, loc, err := resolveTimezone(opts)
+	if err != nil {
+		return nil, err
 	}
 
-	// Load timezone
-	loc, err := time.LoadLocation(timezone)
+	// Resolve the window relative to the current time in the specified timezone
+	w, err := resolve(time.Now().In(loc))
 	if err != nil {
-		return fmt.Errorf("invalid timezone: %w", err)
+		return nil, err
 	}
 
-	// Get actual timezone name for API
-	actualTimezone := getActualTimezone(timezone, loc)
+	events := newEventCache()
+	key, err := cacheKey(opts, actualTimezone, w)
+	if err != nil {
+		return nil, err
+	}
+	if opts.offline {
+		cached, err := loadCached(events, key)
+		if err != nil {
+			return nil, err
+		}
+		return cached, nil
+	}
 
-	// Calculate this week's window (Monday 00:00 to Sunday 24:00 in specified timezone)
-	now := time.Now().In(loc)
+	cliOutput, err := fetchEvents(opts, actualTimezone, w.Start, w.End, calendar.WithFilter(filter), calendar.WithRetryPolicy(retry), calendar.WithWarnings(printWarning))
+	if err != nil {
+		if !opts.cacheFallback {
+			return nil, err
+		}
+		cached, cacheErr := loadCached(events, key)
+		if cacheErr != nil {
+			return nil, err
+		}
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		if cached.FetchedAt != nil {
+			fmt.Fprintf(os.Stderr, "Serving cached events fetched at %s\n", cached.FetchedAt.Local().Format(time.RFC1123))
+		}
+		return cached, nil
+	}
 
-	// Find this Monday
-	weekday := now.Weekday()
-	daysUntilMonday := int(time.Monday - weekday)
-	if daysUntilMonday > 0 {
-		daysUntilMonday -= 7 // Go back to last Monday
+	if events != nil {
+		if err := events.Save(key, cliOutput); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		if err := events.Prune(cacheMaxAge); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to prune event cache: %v\n", err)
+		}
 	}
-	monday := now.AddDate(0, 0, daysUntilMonday)
-	startOfWeek := time.Date(monday.Year(), monday.Month(), monday.Day(), 0, 0, 0, 0, loc)
 
-	// Find next Monday (which is end of this week)
-	endOfWeek := startOfWeek.AddDate(0, 0, 7)
+	return cliOutput, nil
+}
 
-	return fetchAndOutputEvents(format, actualTimezone, startOfWeek, endOfWeek)
+// resolveTimezone resolves --tz ("Local" and Windows names included) to an
+// IANA timezone and its location
+func resolveTimezone(opts *options) (string, *time.Location, error) {
+	actualTimezone, err := timezone.Resolve(opts.timezone)
+	if err != nil {
+		return "", nil, usageErrorf("invalid timezone: %w", err)
+	}
+	loc, err := time.LoadLocation(actualTimezone)
+	if err != nil {
+		return "", nil, usageErrorf("invalid timezone: %w", err)
+	}
+	return actualTimezone, loc, nil
 }
 
-// getActualTimezone converts "Local" to actual IANA timezone name
-func getActualTimezone(timezone string, loc *time.Location) string {
-	actualTimezone := timezone
-	if timezone == "Local" {
-		now := time.Now()
-		actualTimezone, _ = now.Zone()
+// newEventCache returns the cache under ~/.outlook-md/cache, or nil if the
+// home directory cannot be determined
+func newEventCache() *cache.EventCache {
+	dir, err := stateDir()
+	if err != nil {
+		return nil
+	}
+	return cache.NewEventCache(filepath.Join(dir, "cache"))
+}
 
-		if actualTimezone == "" || len(actualTimezone) <= 3 {
-			if loc.String() != "Local" {
-				actualTimezone = loc.String()
-			} else {
-				actualTimezone = "UTC"
-			}
+// cacheKey identifies the cached events of a window for the selected
+// accounts, calendars and filters. It uses the flags as given so that it can
+// be computed without contacting Graph, except that the response statuses
+// are sorted and deduplicated so that their order does not matter.
+func cacheKey(opts *options, timezone string, w window.Window) (string, error) {
+	accounts, err := opts.selectedAccounts()
+	if err != nil {
+		return "", err
+	}
+	filter, err := opts.filter()
+	if err != nil {
+		return "", err
+	}
+	responses := slices.Clone(filter.ResponseStatuses)
+	slices.Sort(responses)
+	responses = slices.Compact(responses)
+
+	parts := []string{
+		timezone,
+		w.Start.UTC().Format(time.RFC3339),
+		w.End.UTC().Format(time.RFC3339),
+		"responses=" + strings.Join(responses, ","),
+		fmt.Sprintf("solo=%t", opts.includeSolo),
+		fmt.Sprintf("hideDeclined=%t", opts.hideDeclined),
+	}
+	for _, acct := range accounts {
+		if !acct.isDefault() || len(accounts) > 1 {
+			parts = append(parts, "account="+acct.name)
+		}
+		calendars, users := opts.selection(acct.profile)
+		for _, name := range calendars {
+			parts = append(parts, "calendar="+name)
+		}
+		for _, user := range users {
+			parts = append(parts, "user="+user)
 		}
 	}
-	return actualTimezone
+	return cache.Key(parts...), nil
 }
 
-// fetchAndOutputEvents is a helper to fetch and format calendar events
-func fetchAndOutputEvents(format string, timezone string, start, end time.Time) error {
-	// Get access token
-	accessToken, err := getAccessToken()
+// loadCached reads the cached events of a window
+func loadCached(events *cache.EventCache, key string) (*schema.CLIOutput, error) {
+	if events == nil {
+		return nil, fmt.Errorf("event cache is not available")
+	}
+	cached, err := events.Load(key)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no cached events for this window (run once while online first)")
+	}
 	if err != nil {
-		return fmt.Errorf("authentication failed: %w", err)
+		return nil, err
 	}
+	return cached, nil
+}
 
-	// Create Graph API client
-	client := calendar.NewGraphClient(accessToken)
+// newTokenSource returns the source of Graph access tokens for an account
+// Priority: 1) Environment variable, 2) Cached token, 3) Device-code flow
+func newTokenSource(acct account) (calendar.TokenSource, error) {
+	// First, check for env var (for testing and manual override)
+	envToken := os.Getenv("OUTLOOK_MD_ACCESS_TOKEN")
+	if envToken != "" {
+		return calendar.StaticToken(envToken), nil
+	}
 
-	// Fetch calendar events
-	ctx := context.Background()
-	events, err := client.GetCalendarView(ctx, start, end, timezone)
+	cfg, err := config.LoadWithProfile(acct.profile)
 	if err != nil {
-		return fmt.Errorf("failed to fetch calendar events: %w", err)
+		return nil, withKind(schema.ErrorKindConfig, fmt.Errorf("failed to load configuration: %w", err))
+	}
+	if cfg.AuthFlow == config.AuthFlowClientCredentials {
+		cloud, err := acct.cloud()
+		if err != nil {
+			return nil, err
+		}
+		return newClientCredentials(cfg, cloud)
+	}
+	return newSession(acct)
+}
+
+// newClientCredentials returns app-only credentials, preferring the
+// certificate when both it and a secret are configured
+func newClientCredentials(cfg *config.Config, cloud auth.Cloud) (*auth.ClientCredentials, error) {
+	if cfg.ClientCertificate != "" {
+		creds, err := auth.NewCertificateCredentials(cfg.ClientID, cfg.TenantID, cloud, cfg.ClientCertificate)
+		if err != nil {
+			return nil, withKind(schema.ErrorKindConfig, err)
+		}
+		return creds, nil
+	}
+	return auth.NewClientSecretCredentials(cfg.ClientID, cfg.TenantID, cloud, cfg.ClientSecret), nil
+}
+
+// newSession returns the sign-in session of an account, backed by its token cache
+func newSession(acct account) (*auth.Session, error) {
+	// Load configuration
+	cfg, err := config.LoadWithProfile(acct.profile)
+	if err != nil {
+		return nil, withKind(schema.ErrorKindConfig, fmt.Errorf("failed to load configuration: %w", err))
+	}
+	if cfg.AuthFlow == config.AuthFlowClientCredentials {
+		return nil, withKind(schema.ErrorKindConfig, fmt.Errorf("%s uses app-only auth (auth_flow = %s), which has no user sign-in", acct.name, cfg.AuthFlow))
+	}
+
+	cloud, err := acct.cloud()
+	if err != nil {
+		return nil, err
+	}
+
+	cache, err := newTokenCache(acct)
+	if err != nil {
+		return nil, err
+	}
+	var authenticator auth.Authenticator = auth.NewDeviceCodeAuthenticator(cfg.ClientID, cfg.TenantID, cloud)
+	if cfg.AuthFlow == config.AuthFlowBrowser {
+		authenticator = auth.NewAuthCodeAuthenticator(cfg.ClientID, cfg.TenantID, cloud)
+	}
+	return auth.NewSession(cfg.ClientID, cfg.TenantID, cloud, cache, authenticator), nil
+}
+
+// newTokenCache returns the token cache of an account, creating its directory
+func newTokenCache(acct account) (*auth.TokenCache, error) {
+	// Determine cache file location
+	cacheDir, err := stateDir()
+	if err != nil {
+		return nil, err
+	}
+	cacheFile := acct.tokenCachePath(cacheDir)
+
+	// Create cache directory if it doesn't exist
+	if err := os.MkdirAll(filepath.Dir(cacheFile), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	return auth.NewTokenCache(cacheFile), nil
+}
+
+// fetchEvents is a helper to fetch calendar events into a CLIOutput,
+// merging the calendars selected with --calendar and the accounts selected
+// with --account
+func fetchEvents(opts *options, timezone string, start, end time.Time, clientOpts ...calendar.Option) (*schema.CLIOutput, error) {
+	accounts, err := opts.selectedAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	var events []schema.CalendarEvent
+	var removed []schema.RemovedEvent
+	if len(accounts) == 1 {
+		events, removed, err = fetchAccountEvents(opts, accounts[0], timezone, start, end, clientOpts...)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if err := checkCredentialEnv(accounts); err != nil {
+			return nil, err
+		}
+
+		// Label events with their account so merged agendas can tell them apart
+		lists := make([][]schema.CalendarEvent, 0, len(accounts))
+		for _, acct := range accounts {
+			list, gone, err := fetchAccountEvents(opts, acct, timezone, start, end, clientOpts...)
+			if err != nil {
+				return nil, fmt.Errorf("account %s: %w", acct.name, err)
+			}
+			for i := range list {
+				list[i].Account = acct.name
+			}
+			for i := range gone {
+				gone[i].Account = acct.name
+			}
+			lists = append(lists, list)
+			removed = append(removed, gone...)
+		}
+		events = calendar.MergeEvents(lists...)
 	}
 
 	// Build output
+	fetchedAt := time.Now().UTC().Truncate(time.Second)
 	cliOutput := &schema.CLIOutput{
 		Version:  1,
 		Timezone: timezone,
@@ -278,11 +756,180 @@ func fetchAndOutputEvents(format string, timezone string, start, end time.Time)
 			Start: start,
 			End:   end,
 		},
-		Events: events,
+		Events:    events,
+		Removed:   removed,
+		FetchedAt: &fetchedAt,
+	}
+
+	return cliOutput, nil
+}
+
+// fetchAccountEvents signs in to an account and fetches the events of the
+// calendars selected for it
+func fetchAccountEvents(opts *options, acct account, timezone string, start, end time.Time, clientOpts ...calendar.Option) ([]schema.CalendarEvent, []schema.RemovedEvent, error) {
+	tokens, err := newTokenSource(acct)
+	if err != nil {
+		return nil, nil, withKind(schema.ErrorKindAuth, fmt.Errorf("authentication failed: %w", err))
+	}
+	names, users := opts.selection(acct.profile)
+	if _, ok := tokens.(*auth.ClientCredentials); ok {
+		if err := checkAppOnlySelection(names, users); err != nil {
+			return nil, nil, err
+		}
 	}
+	client, err := newGraphClient(acct, tokens, clientOpts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Fetch calendar events
+	ctx := context.Background()
+	sources, err := resolveSources(ctx, client, names, users)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if opts.delta {
+		return fetchDeltaEvents(ctx, client, acct, sources, timezone, start, end)
+	}
+	if len(sources) == 0 {
+		events, err := client.GetCalendarView(ctx, start, end, timezone)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch calendar events: %w", err)
+		}
+		return events, nil, nil
+	}
+
+	lists := make([][]schema.CalendarEvent, 0, len(sources))
+	for _, src := range sources {
+		list, err := client.GetCalendarViewFor(ctx, src, start, end, timezone)
+		if err != nil {
+			return nil, nil, sourceError(src, err)
+		}
+		lists = append(lists, list)
+	}
+	return calendar.MergeEvents(lists...), nil, nil
+}
+
+// fetchDeltaEvents runs a delta query per source, resuming from and then
+// updating the state persisted under ~/.outlook-md/delta
+func fetchDeltaEvents(ctx context.Context, client calendar.GraphClient, acct account, sources []calendar.Source, timezone string, start, end time.Time) ([]schema.CalendarEvent, []schema.RemovedEvent, error) {
+	dir, err := stateDir()
+	if err != nil {
+		return nil, nil, err
+	}
+	deltaDir := acct.deltaDir(dir)
+	if len(sources) == 0 {
+		sources = []calendar.Source{{}} // The signed-in user's default calendar
+	}
+
+	var removed []schema.RemovedEvent
+	lists := make([][]schema.CalendarEvent, 0, len(sources))
+	for _, src := range sources {
+		path := filepath.Join(deltaDir, calendar.DeltaKey(src, start, end, timezone)+".json")
+		state, err := calendar.LoadDeltaState(path)
+		if err != nil {
+			// A corrupt state file only costs a full sync
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			state = nil
+		}
+
+		result, err := client.GetCalendarViewDelta(ctx, src, start, end, timezone, state)
+		if err != nil {
+			return nil, nil, sourceError(src, err)
+		}
+		if err := calendar.SaveDeltaState(path, result.State); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+
+		lists = append(lists, result.Events)
+		removed = append(removed, result.Removed...)
+	}
+
+	if err := calendar.PruneDeltaStates(deltaDir, deltaStateMaxAge); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to prune delta state: %v\n", err)
+	}
+
+	return calendar.MergeEvents(lists...), removed, nil
+}
+
+// sourceError describes a failure to fetch events from a calendar source
+func sourceError(src calendar.Source, err error) error {
+	switch {
+	case src.User != "":
+		return fmt.Errorf("failed to fetch events for user %s (is their calendar shared with you?): %w", src.User, err)
+	case src.CalendarID != "":
+		return fmt.Errorf("failed to fetch events from calendar %q: %w", src.Name, err)
+	default:
+		return fmt.Errorf("failed to fetch calendar events: %w", err)
+	}
+}
+
+// stateDir returns ~/.outlook-md, where tokens and sync state are kept
+func stateDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".outlook-md"), nil
+}
+
+// resolveSources maps calendar names or IDs and user mailboxes to calendar
+// sources; none means the signed-in user's default calendar
+func resolveSources(ctx context.Context, client calendar.GraphClient, names, users []string) ([]calendar.Source, error) {
+	var sources []calendar.Source
+
+	if len(names) > 0 {
+		calendars, err := client.ListCalendars(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list calendars: %w", err)
+		}
+		for _, name := range names {
+			cal, ok := calendar.FindCalendar(calendars, name)
+			if !ok {
+				return nil, usageErrorf("calendar not found: %s (run 'outlook-md calendars' to list them)", name)
+			}
+			sources = append(sources, calendar.Source{CalendarID: cal.ID, Name: cal.Name})
+		}
+	}
+
+	for _, user := range users {
+		sources = append(sources, calendar.Source{User: user, Name: user})
+	}
+
+	return sources, nil
+}
+
+// checkAppOnlySelection rejects calendar selections that need a signed-in
+// user: app-only tokens can only read the calendars of users named with --user
+func checkAppOnlySelection(names, users []string) error {
+	if len(names) > 0 {
+		return usageErrorf("--calendar is not available with app-only auth; select calendars with --user")
+	}
+	if len(users) == 0 {
+		return usageErrorf("app-only auth requires --user to select whose calendar to read")
+	}
+	return nil
+}
+
+// newGraphClient authenticates with an account's tokens and creates a
+// client for the account's Graph API
+func newGraphClient(acct account, tokens calendar.TokenSource, clientOpts ...calendar.Option) (calendar.GraphClient, error) {
+	cloud, err := acct.cloud()
+	if err != nil {
+		return nil, err
+	}
+
+	// Sign in up front so that prompts appear before any output
+	if _, err := tokens.Token(); err != nil {
+		return nil, withKind(schema.ErrorKindAuth, fmt.Errorf("authentication failed: %w", err))
+	}
+	return calendar.NewGraphClientWithBaseURL(tokens, cloud.GraphURL(), clientOpts...), nil
+}
 
-	// Format and write output
-	if err := output.FormatJSON(cliOutput, os.Stdout); err != nil {
+// writeOutput formats the output and writes it to stdout
+func writeOutput(format string, cliOutput *schema.CLIOutput) error {
+	if err := output.Format(format, cliOutput, os.Stdout); err != nil {
 		return fmt.Errorf("failed to format output: %w", err)
 	}
 
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/obsidian-outlook-sync/outlook-md/internal/config"
+	"github.com/obsidian-outlook-sync/outlook-md/internal/window"
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+	"golang.org/x/oauth2"
+)
+
+// standInEvents is a calendar view with one accepted meeting
+const standInEvents = `{"value": [{"id": "evt-1", "subject": "Standup", "isAllDay": false,
+	"start": {"dateTime": "2026-01-07T09:00:00", "timeZone": "UTC"},
+	"end": {"dateTime": "2026-01-07T09:30:00", "timeZone": "UTC"},
+	"organizer": {"emailAddress": {"name": "Alice", "address": "alice@contoso.com"}},
+	"responseStatus": {"response": "accepted"}}]}`
+
+// newStandInCloud starts stand-ins for the identity platform and Graph,
+// pointing the environment at them. The token endpoint checks the grant and
+// hands out "stand-in-token"; Graph answers calendar views at path.
+func newStandInCloud(t *testing.T, grantType, path string) {
+	t.Helper()
+	for _, env := range []string{"OUTLOOK_MD_ACCESS_TOKEN", "OUTLOOK_MD_CLOUD", "OUTLOOK_MD_AUTH_FLOW", "OUTLOOK_MD_CLIENT_SECRET", "OUTLOOK_MD_CLIENT_CERTIFICATE"} {
+		t.Setenv(env, "")
+	}
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("OUTLOOK_MD_CLIENT_ID", "client")
+	t.Setenv("OUTLOOK_MD_TENANT_ID", "tenant")
+
+	graph := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer stand-in-token" {
+			t.Errorf("Unexpected Authorization header %q", r.Header.Get("Authorization"))
+		}
+		if r.URL.Path != path {
+			t.Errorf("Expected request to %s, got %s", path, r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(standInEvents))
+	}))
+	t.Cleanup(graph.Close)
+
+	authority := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.URL.Path != "/tenant/oauth2/v2.0/token" || r.Form.Get("grant_type") != grantType {
+			t.Errorf("Unexpected token request to %s: %v", r.URL.Path, r.Form)
+		}
+		// Refreshes keep the scopes of the sign-in and send none
+		if grantType == "client_credentials" && r.Form.Get("scope") != graph.URL+"/.default" {
+			t.Errorf("Expected scopes qualified with the Graph host, got %q", r.Form.Get("scope"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "stand-in-token", "refresh_token": "refresh-2", "token_type": "Bearer", "expires_in": 3600}`))
+	}))
+	t.Cleanup(authority.Close)
+
+	t.Setenv("OUTLOOK_MD_AUTHORITY_HOST", authority.URL)
+	t.Setenv("OUTLOOK_MD_GRAPH_HOST", graph.URL)
+}
+
+// TestFetchEventsStandInCloud runs the sign-in and fetch path end to end
+// against local stand-ins for the identity platform and Graph
+func TestFetchEventsStandInCloud(t *testing.T) {
+	start := time.Date(2026, 1, 7, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	t.Run("cached sign-in", func(t *testing.T) {
+		newStandInCloud(t, "refresh_token", "/v1.0/me/calendarView")
+
+		// An expired cached token is refreshed at the stand-in authority
+		cache, err := newTokenCache(account{name: config.DefaultProfile})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := cache.Save(&oauth2.Token{AccessToken: "expired", RefreshToken: "refresh-1", Expiry: time.Now().Add(-time.Hour)}); err != nil {
+			t.Fatal(err)
+		}
+
+		out, err := fetchEvents(&options{settings: config.Profile{Name: config.DefaultProfile}}, "UTC", start, end)
+		if err != nil {
+			t.Fatalf("fetchEvents failed: %v", err)
+		}
+		if len(out.Events) != 1 || out.Events[0].Subject != "Standup" {
+			t.Errorf("Unexpected events: %+v", out.Events)
+		}
+		if cached, err := cache.Load(); err != nil || cached.AccessToken != "stand-in-token" {
+			t.Errorf("Expected refreshed token to be cached, got %+v, %v", cached, err)
+		}
+	})
+
+	t.Run("app-only", func(t *testing.T) {
+		newStandInCloud(t, "client_credentials", "/v1.0/users/bob@contoso.com/calendarView")
+		t.Setenv("OUTLOOK_MD_AUTH_FLOW", config.AuthFlowClientCredentials)
+		t.Setenv("OUTLOOK_MD_CLIENT_SECRET", "s3cret")
+
+		opts := &options{users: stringList{"bob@contoso.com"}}
+		out, err := fetchEvents(opts, "UTC", start, end)
+		if err != nil {
+			t.Fatalf("fetchEvents failed: %v", err)
+		}
+		if len(out.Events) != 1 {
+			t.Errorf("Expected 1 event, got %+v", out.Events)
+		}
+	})
+}
+
+// TestAccountCloud verifies that invalid cloud settings are config errors
+func TestAccountCloud(t *testing.T) {
+	t.Setenv("OUTLOOK_MD_CLOUD", "")
+	t.Setenv("OUTLOOK_MD_AUTHORITY_HOST", "")
+	t.Setenv("OUTLOOK_MD_GRAPH_HOST", "")
+
+	cloud, err := account{profile: config.Profile{Cloud: "usgov"}}.cloud()
+	if err != nil || cloud.GraphURL() != "https://graph.microsoft.us/v1.0" {
+		t.Errorf("Expected GCC High Graph, got %+v, %v", cloud, err)
+	}
+
+	t.Setenv("OUTLOOK_MD_CLOUD", "mars")
+	if _, err := (account{}).cloud(); err == nil || classifyError(err) != schema.ErrorKindConfig {
+		t.Errorf("Expected config error for an unknown cloud, got %v", err)
+	}
+}
+
+// TestCacheKeyResponses verifies that the order, case and repetition of
+// --responses do not change the cache key
+func TestCacheKeyResponses(t *testing.T) {
+	w := window.Day(2026, 1, 7, time.UTC)
+	key := func(responses string) string {
+		t.Helper()
+		k, err := cacheKey(&options{responses: responses}, "UTC", w)
+		if err != nil {
+			t.Fatalf("cacheKey(%q) failed: %v", responses, err)
+		}
+		return k
+	}
+
+	want := key("accepted,organizer")
+	for _, responses := range []string{"organizer,accepted", "Organizer, accepted,accepted"} {
+		if got := key(responses); got != want {
+			t.Errorf("Expected %q to share the key of %q", responses, "accepted,organizer")
+		}
+	}
+	if key("accepted") == want {
+		t.Error("Expected different response statuses to change the key")
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/obsidian-outlook-sync/outlook-md/internal/auth"
+	"github.com/obsidian-outlook-sync/outlook-md/internal/calendar"
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+// handleSeriesCommand lists the past and future occurrences of the
+// recurring series an event belongs to
+func handleSeriesCommand(opts *options, args []string) error {
+	fs := newCommandFlagSet("series", opts)
+	fromFlag := fs.String("from", "-90d", "Start of range (date expression)")
+	toFlag := fs.String("to", "+90d", "End of range, inclusive (date expression)")
+	rest, err := parseCommandArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) == 0 {
+		return usageErrorf("series requires an event ID")
+	}
+	if len(rest) > 1 {
+		return usageErrorf("unexpected arguments to series: %s", strings.Join(rest[1:], " "))
+	}
+	eventID := rest[0]
+
+	if err := validateFormat(opts.format); err != nil {
+		return err
+	}
+	if opts.offline || opts.delta {
+		return usageErrorf("series is not available with --offline or --delta")
+	}
+	if len(opts.calendars) > 0 {
+		return usageErrorf("series reads the mailbox of the event; --calendar does not apply")
+	}
+	if len(opts.users) > 1 {
+		return usageErrorf("series reads one mailbox at a time")
+	}
+	resolve, err := parseRange(opts, *fromFlag, *toFlag)
+	if err != nil {
+		return err
+	}
+	retry, err := opts.retryPolicy()
+	if err != nil {
+		return err
+	}
+	filter, err := opts.filter()
+	if err != nil {
+		return err
+	}
+
+	actualTimezone, loc, err := resolveTimezone(opts)
+	if err != nil {
+		return err
+	}
+	w, err := resolve(time.Now().In(loc))
+	if err != nil {
+		return err
+	}
+	start, end := w.Start, w.End
+
+	accounts, err := opts.selectedAccounts()
+	if err != nil {
+		return err
+	}
+	if len(accounts) > 1 {
+		return usageErrorf("series reads one account at a time")
+	}
+	tokens, err := newTokenSource(accounts[0])
+	if err != nil {
+		return withKind(schema.ErrorKindAuth, fmt.Errorf("authentication failed: %w", err))
+	}
+	if _, ok := tokens.(*auth.ClientCredentials); ok {
+		if err := checkAppOnlySelection(nil, opts.users); err != nil {
+			return err
+		}
+	}
+	client, err := newGraphClient(accounts[0], tokens, calendar.WithFilter(filter), calendar.WithRetryPolicy(retry))
+	if err != nil {
+		return err
+	}
+
+	var src calendar.Source
+	if len(opts.users) == 1 {
+		src.User = opts.users[0]
+	}
+	events, err := client.GetSeriesInstances(context.Background(), src, eventID, start, end, actualTimezone)
+	if err != nil {
+		return sourceError(src, err)
+	}
+
+	fetchedAt := time.Now().UTC().Truncate(time.Second)
+	return writeOutput(opts.format, &schema.CLIOutput{
+		Version:   1,
+		Timezone:  actualTimezone,
+		Window:    schema.TimeWindow{Start: start, End: end},
+		Events:    events,
+		FetchedAt: &fetchedAt,
+	})
+}
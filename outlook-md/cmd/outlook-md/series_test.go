@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+// TestSeriesCommandUsage verifies that invalid series arguments are usage
+// errors reported before signing in
+func TestSeriesCommandUsage(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"no event", nil},
+		{"two events", []string{"AAMk1", "AAMk2"}},
+		{"calendar", []string{"AAMk1", "--calendar", "Work"}},
+		{"offline", []string{"AAMk1", "--offline"}},
+		{"several users", []string{"AAMk1", "--user", "a@example.com", "--user", "b@example.com"}},
+		{"invalid from", []string{"AAMk1", "--from", "someday"}},
+		{"reversed window", []string{"AAMk1", "--from", "+3d", "--to", "today"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &options{format: "json", timezone: "UTC", weekStart: "monday", maxAttempts: 1}
+			err := handleSeriesCommand(opts, tt.args)
+			if err == nil || classifyError(err) != schema.ErrorKindUsage {
+				t.Errorf("Expected usage error, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// authCodeTimeout is how long the browser sign-in may take
+const authCodeTimeout = 5 * time.Minute
+
+// AuthCodeAuthenticator handles the OAuth2 authorization code flow with PKCE,
+// receiving the code on a temporary loopback HTTP listener. It is an
+// alternative to the device code flow for tenants that block it.
+type AuthCodeAuthenticator struct {
+	clientID string
+	scopes   []string
+	endpoint oauth2.Endpoint
+
+	// openBrowser opens the authorize URL; the URL is printed either way
+	openBrowser func(url string) error
+}
+
+// NewAuthCodeAuthenticator creates a new authorization code authenticator
+// for a tenant in the given cloud
+func NewAuthCodeAuthenticator(clientID, tenantID string, cloud Cloud) *AuthCodeAuthenticator {
+	return &AuthCodeAuthenticator{
+		clientID:    clientID,
+		scopes:      cloud.Scopes(),
+		endpoint:    cloud.Endpoint(tenantID),
+		openBrowser: OpenBrowser,
+	}
+}
+
+// authCodeResult is the outcome of the redirect to the loopback listener
+type authCodeResult struct {
+	code string
+	err  error
+}
+
+// Authenticate performs the authorization code flow and returns a token
+func (a *AuthCodeAuthenticator) Authenticate(ctx context.Context) (*oauth2.Token, error) {
+	// Listen on a free loopback port; Azure AD accepts any port for
+	// http://127.0.0.1 redirect URIs of public clients. The redirect names the
+	// address listened on, as localhost may resolve to ::1 instead.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to start redirect listener: %w", err)
+	}
+	defer listener.Close()
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	config := &oauth2.Config{
+		ClientID:    a.clientID,
+		Scopes:      a.scopes,
+		Endpoint:    a.endpoint,
+		RedirectURL: fmt.Sprintf("http://127.0.0.1:%d/", port),
+	}
+
+	state, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	verifier := oauth2.GenerateVerifier()
+	authURL := config.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier))
+
+	results := make(chan authCodeResult, 1)
+	server := &http.Server{Handler: redirectHandler(state, results), ReadHeaderTimeout: 10 * time.Second}
+	go server.Serve(listener)
+	defer server.Close()
+
+	// Display instructions to user on stderr
+	fmt.Fprintf(os.Stderr, "\nTo authenticate, open this URL in your browser:\n%s\n", authURL)
+	if a.openBrowser != nil {
+		if err := a.openBrowser(authURL); err != nil {
+			fmt.Fprintf(os.Stderr, "(could not open a browser: %v; open the URL above by hand)\n", err)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "\nWaiting for authentication...\n\n")
+
+	ctx, cancel := context.WithTimeout(ctx, authCodeTimeout)
+	defer cancel()
+
+	var result authCodeResult
+	select {
+	case result = <-results:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("authorization code flow failed: %w", ctx.Err())
+	}
+	if result.err != nil {
+		return nil, fmt.Errorf("authorization code flow failed: %w", result.err)
+	}
+
+	token, err := config.Exchange(ctx, result.code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("authorization code flow failed: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "✓ Authentication successful!\n\n")
+
+	return token, nil
+}
+
+// redirectHandler receives the authorization response and reports the code
+// (or the error) on results once
+func redirectHandler(state string, results chan<- authCodeResult) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+
+		q := r.URL.Query()
+		var result authCodeResult
+		switch {
+		case q.Get("state") != state:
+			// Not our request (or a forged one); keep waiting
+			http.Error(w, "Invalid state", http.StatusBadRequest)
+			return
+		case q.Get("error") != "":
+			result.err = fmt.Errorf("%s: %s", q.Get("error"), q.Get("error_description"))
+		case q.Get("code") == "":
+			result.err = errors.New("no authorization code in the redirect")
+		default:
+			result.code = q.Get("code")
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if result.err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "<p>Sign-in failed: %s</p>", html.EscapeString(result.err.Error()))
+		} else {
+			fmt.Fprint(w, "<p>Signed in to outlook-md. You can close this window.</p>")
+		}
+
+		select {
+		case results <- result:
+		default: // Already answered
+		}
+	})
+}
+
+// randomString returns a random URL-safe string for the state parameter
+func randomString() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate state: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// OpenBrowser opens a URL with the platform's default handler. It waits for
+// the launcher, which hands the URL over and exits, so that a missing or
+// failing launcher is reported.
+func OpenBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%s: %w: %s", cmd.Args[0], err, msg)
+		}
+		return fmt.Errorf("%s: %w", cmd.Args[0], err)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+// fakeAuthorizationServer implements the authorize and token endpoints of
+// the authorization code flow with PKCE; denyWith makes /authorize redirect
+// with that error instead of a code
+func fakeAuthorizationServer(t *testing.T, denyWith string) *httptest.Server {
+	var challenge, redirectURI string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/authorize", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("client_id") != "client" || q.Get("response_type") != "code" || q.Get("code_challenge_method") != "S256" {
+			t.Errorf("Unexpected authorize request: %v", q)
+		}
+		if !strings.Contains(q.Get("scope"), "Calendars.Read") {
+			t.Errorf("Expected calendar scope, got %q", q.Get("scope"))
+		}
+		challenge, redirectURI = q.Get("code_challenge"), q.Get("redirect_uri")
+		if !strings.HasPrefix(redirectURI, "http://127.0.0.1:") {
+			t.Errorf("Expected loopback redirect, got %q", redirectURI)
+		}
+
+		params := url.Values{"state": {q.Get("state")}}
+		if denyWith != "" {
+			params.Set("error", denyWith)
+			params.Set("error_description", "The user denied the request.")
+		} else {
+			params.Set("code", "auth-code-123")
+		}
+		http.Redirect(w, r, redirectURI+"?"+params.Encode(), http.StatusFound)
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("grant_type") != "authorization_code" || r.Form.Get("code") != "auth-code-123" || r.Form.Get("redirect_uri") != redirectURI {
+			t.Errorf("Unexpected token request: %v", r.Form)
+		}
+		sum := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+		if base64.RawURLEncoding.EncodeToString(sum[:]) != challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": "invalid_grant", "error_description": "PKCE verification failed."}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "browser-token", "refresh_token": "browser-refresh", "token_type": "Bearer", "expires_in": 3600}`))
+	})
+	return httptest.NewServer(mux)
+}
+
+// newTestAuthCodeAuthenticator returns an authenticator for the fake server
+// whose "browser" follows the authorize URL's redirect to the listener
+func newTestAuthCodeAuthenticator(server *httptest.Server) *AuthCodeAuthenticator {
+	a := NewAuthCodeAuthenticator("client", "tenant", GlobalCloud)
+	a.endpoint = oauth2.Endpoint{AuthURL: server.URL + "/authorize", TokenURL: server.URL + "/token", AuthStyle: oauth2.AuthStyleInParams}
+	a.openBrowser = func(authURL string) error {
+		go func() {
+			resp, err := http.Get(authURL)
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+		return nil
+	}
+	return a
+}
+
+// TestAuthCodeFlow verifies the PKCE exchange through the loopback redirect
+func TestAuthCodeFlow(t *testing.T) {
+	server := fakeAuthorizationServer(t, "")
+	defer server.Close()
+
+	token, err := newTestAuthCodeAuthenticator(server).Authenticate(context.Background())
+	if err != nil {
+		t.Fatalf("Authenticate failed: %v", err)
+	}
+	if token.AccessToken != "browser-token" || token.RefreshToken != "browser-refresh" {
+		t.Errorf("Unexpected token: %+v", token)
+	}
+}
+
+// TestAuthCodeFlowDenied verifies that an error redirect fails the sign-in
+func TestAuthCodeFlowDenied(t *testing.T) {
+	server := fakeAuthorizationServer(t, "access_denied")
+	defer server.Close()
+
+	_, err := newTestAuthCodeAuthenticator(server).Authenticate(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "access_denied") {
+		t.Errorf("Expected access_denied error, got %v", err)
+	}
+}
+
+// TestRedirectHandler verifies that redirects with another state are ignored
+func TestRedirectHandler(t *testing.T) {
+	results := make(chan authCodeResult, 1)
+	handler := redirectHandler("expected-state", results)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/?code=stolen&state=other", nil))
+	if rec.Code != http.StatusBadRequest || len(results) != 0 {
+		t.Errorf("Expected forged redirect to be rejected, got %d with %d results", rec.Code, len(results))
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/?code=good&state=expected-state", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected 200, got %d", rec.Code)
+	}
+	if result := <-results; result.code != "good" || result.err != nil {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+}
+
+// TestOpenBrowserFailure verifies that a failing launcher is reported
+func TestOpenBrowserFailure(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("uses a fake xdg-open")
+	}
+	dir := t.TempDir()
+	t.Setenv("PATH", dir)
+	if err := OpenBrowser("https://example.com"); err == nil {
+		t.Error("Expected an error without xdg-open")
+	}
+
+	script := "#!/bin/sh\necho 'no method available' >&2\nexit 3\n"
+	if err := os.WriteFile(filepath.Join(dir, "xdg-open"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	err := OpenBrowser("https://example.com")
+	if err == nil || !strings.Contains(err.Error(), "no method available") {
+		t.Errorf("Expected the launcher's message, got %v", err)
+	}
+}
@@ -0,0 +1,201 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// assertionLifetime is how long a client assertion is valid
+const assertionLifetime = 10 * time.Minute
+
+// ClientCredentials obtains app-only tokens with the OAuth2 client
+// credentials grant, authenticating the app with a client secret or a
+// certificate-signed JWT assertion. Tokens are kept in memory only.
+type ClientCredentials struct {
+	clientID string
+	tokenURL string
+	scopes   []string
+
+	secret string            // Client secret, or
+	cert   *x509.Certificate // certificate and its private key
+	key    *rsa.PrivateKey
+
+	token *oauth2.Token
+}
+
+// NewClientSecretCredentials creates app-only credentials for a tenant in
+// the given cloud using a client secret
+func NewClientSecretCredentials(clientID, tenantID string, cloud Cloud, secret string) *ClientCredentials {
+	return &ClientCredentials{
+		clientID: clientID,
+		tokenURL: cloud.Endpoint(tenantID).TokenURL,
+		scopes:   cloud.AppScopes(),
+		secret:   secret,
+	}
+}
+
+// NewCertificateCredentials creates app-only credentials for a tenant in
+// the given cloud using the certificate and RSA private key in a PEM file
+func NewCertificateCredentials(clientID, tenantID string, cloud Cloud, pemFile string) (*ClientCredentials, error) {
+	data, err := os.ReadFile(pemFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client certificate: %w", err)
+	}
+	cert, key, err := parseCertificatePEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid client certificate %s: %w", pemFile, err)
+	}
+
+	return &ClientCredentials{
+		clientID: clientID,
+		tokenURL: cloud.Endpoint(tenantID).TokenURL,
+		scopes:   cloud.AppScopes(),
+		cert:     cert,
+		key:      key,
+	}, nil
+}
+
+// Token returns the current access token, requesting a new one if it has expired
+func (c *ClientCredentials) Token() (string, error) {
+	if c.token.Valid() {
+		return c.token.AccessToken, nil
+	}
+	return c.Refresh()
+}
+
+// Refresh requests a new access token (there is no refresh token in the
+// client credentials grant; the app authenticates again)
+func (c *ClientCredentials) Refresh() (string, error) {
+	config := &clientcredentials.Config{
+		ClientID:     c.clientID,
+		ClientSecret: c.secret,
+		TokenURL:     c.tokenURL,
+		Scopes:       c.scopes,
+		AuthStyle:    oauth2.AuthStyleInParams,
+	}
+	if c.cert != nil {
+		assertion, err := c.assertion(time.Now())
+		if err != nil {
+			return "", err
+		}
+		config.EndpointParams = url.Values{
+			"client_assertion_type": {"urn:ietf:params:oauth:client-assertion-type:jwt-bearer"},
+			"client_assertion":      {assertion},
+		}
+	}
+
+	token, err := config.Token(context.Background())
+	if err != nil {
+		return "", fmt.Errorf("client credentials authentication failed: %w", err)
+	}
+	c.token = token
+	return token.AccessToken, nil
+}
+
+// assertion builds the RS256-signed JWT identifying the app, as described
+// in Microsoft's certificate credentials documentation
+func (c *ClientCredentials) assertion(now time.Time) (string, error) {
+	thumbprint := sha1.Sum(c.cert.Raw)
+	header := map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+		"x5t": base64.RawURLEncoding.EncodeToString(thumbprint[:]),
+	}
+
+	jti, err := randomString()
+	if err != nil {
+		return "", err
+	}
+	claims := map[string]interface{}{
+		"aud": c.tokenURL,
+		"iss": c.clientID,
+		"sub": c.clientID,
+		"jti": jti,
+		"nbf": now.Unix(),
+		"iat": now.Unix(),
+		"exp": now.Add(assertionLifetime).Unix(),
+	}
+
+	var segments []string
+	for _, part := range []interface{}{header, claims} {
+		data, err := json.Marshal(part)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode client assertion: %w", err)
+		}
+		segments = append(segments, base64.RawURLEncoding.EncodeToString(data))
+	}
+	signingInput := segments[0] + "." + segments[1]
+
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, c.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign client assertion: %w", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// parseCertificatePEM reads the first certificate and the RSA private key
+// (PKCS #1 or PKCS #8) from PEM data
+func parseCertificatePEM(data []byte) (*x509.Certificate, *rsa.PrivateKey, error) {
+	var cert *x509.Certificate
+	var key *rsa.PrivateKey
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		switch block.Type {
+		case "CERTIFICATE":
+			if cert != nil {
+				continue
+			}
+			parsed, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, nil, err
+			}
+			cert = parsed
+		case "RSA PRIVATE KEY":
+			parsed, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, nil, err
+			}
+			key = parsed
+		case "PRIVATE KEY":
+			parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, nil, err
+			}
+			rsaKey, ok := parsed.(*rsa.PrivateKey)
+			if !ok {
+				return nil, nil, errors.New("private key is not an RSA key")
+			}
+			key = rsaKey
+		}
+	}
+
+	if cert == nil {
+		return nil, nil, errors.New("no CERTIFICATE block found")
+	}
+	if key == nil {
+		return nil, nil, errors.New("no RSA private key found")
+	}
+	return cert, key, nil
+}
@@ -0,0 +1,196 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeTokenEndpoint answers client credentials requests, passing the form
+// to check for validation; it counts the requests it receives
+func fakeTokenEndpoint(t *testing.T, requests *int, check func(url.Values) bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		r.ParseForm()
+		if r.Form.Get("grant_type") != "client_credentials" || r.Form.Get("client_id") != "client" {
+			t.Errorf("Unexpected token request: %v", r.Form)
+		}
+		if r.Form.Get("scope") != "https://graph.microsoft.com/.default" {
+			t.Errorf("Expected .default scope, got %q", r.Form.Get("scope"))
+		}
+		if !check(r.Form) {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error": "invalid_client", "error_description": "Invalid client secret provided."}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "app-token", "token_type": "Bearer", "expires_in": 3600}`))
+	}))
+}
+
+// TestClientSecretCredentials verifies the secret is sent and the token reused
+func TestClientSecretCredentials(t *testing.T) {
+	var requests int
+	server := fakeTokenEndpoint(t, &requests, func(form url.Values) bool {
+		return form.Get("client_secret") == "s3cret"
+	})
+	defer server.Close()
+
+	creds := NewClientSecretCredentials("client", "tenant", GlobalCloud, "s3cret")
+	creds.tokenURL = server.URL
+
+	for i := 0; i < 2; i++ {
+		token, err := creds.Token()
+		if err != nil || token != "app-token" {
+			t.Fatalf("Token() = %q, %v", token, err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Expected cached token to be reused, got %d requests", requests)
+	}
+
+	if _, err := creds.Refresh(); err != nil || requests != 2 {
+		t.Errorf("Expected Refresh to request a new token, got %d requests, %v", requests, err)
+	}
+}
+
+// TestClientSecretCredentialsRejected verifies that a wrong secret fails
+func TestClientSecretCredentialsRejected(t *testing.T) {
+	var requests int
+	server := fakeTokenEndpoint(t, &requests, func(form url.Values) bool {
+		return form.Get("client_secret") == "s3cret"
+	})
+	defer server.Close()
+
+	creds := NewClientSecretCredentials("client", "tenant", GlobalCloud, "wrong")
+	creds.tokenURL = server.URL
+
+	_, err := creds.Token()
+	if err == nil || !strings.Contains(err.Error(), "invalid_client") {
+		t.Errorf("Expected invalid_client error, got %v", err)
+	}
+}
+
+// writeTestCertificate writes a self-signed certificate and its PKCS #8 key
+// to a PEM file
+func writeTestCertificate(t *testing.T) (string, *x509.Certificate) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "outlook-md"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	data = append(data, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})...)
+	path := filepath.Join(t.TempDir(), "app.pem")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path, cert
+}
+
+// TestCertificateCredentials verifies the signed client assertion
+func TestCertificateCredentials(t *testing.T) {
+	pemFile, cert := writeTestCertificate(t)
+
+	var requests int
+	var server *httptest.Server
+	server = fakeTokenEndpoint(t, &requests, func(form url.Values) bool {
+		if form.Get("client_secret") != "" {
+			t.Errorf("Expected no client secret, got %q", form.Get("client_secret"))
+		}
+		if form.Get("client_assertion_type") != "urn:ietf:params:oauth:client-assertion-type:jwt-bearer" {
+			t.Errorf("Unexpected assertion type %q", form.Get("client_assertion_type"))
+		}
+
+		parts := strings.Split(form.Get("client_assertion"), ".")
+		if len(parts) != 3 {
+			t.Errorf("Expected a JWT, got %q", form.Get("client_assertion"))
+			return false
+		}
+		signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+		digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		if err := rsa.VerifyPKCS1v15(cert.PublicKey.(*rsa.PublicKey), crypto.SHA256, digest[:], signature); err != nil {
+			t.Errorf("Invalid assertion signature: %v", err)
+			return false
+		}
+
+		var header map[string]string
+		var claims map[string]interface{}
+		for i, v := range []interface{}{&header, &claims} {
+			data, _ := base64.RawURLEncoding.DecodeString(parts[i])
+			if err := json.Unmarshal(data, v); err != nil {
+				t.Fatalf("Invalid assertion segment: %v", err)
+			}
+		}
+		thumbprint := sha1.Sum(cert.Raw)
+		if header["alg"] != "RS256" || header["x5t"] != base64.RawURLEncoding.EncodeToString(thumbprint[:]) {
+			t.Errorf("Unexpected assertion header: %v", header)
+		}
+		if claims["aud"] != server.URL || claims["iss"] != "client" || claims["sub"] != "client" || claims["jti"] == "" {
+			t.Errorf("Unexpected assertion claims: %v", claims)
+		}
+		return true
+	})
+	defer server.Close()
+
+	creds, err := NewCertificateCredentials("client", "tenant", GlobalCloud, pemFile)
+	if err != nil {
+		t.Fatalf("NewCertificateCredentials failed: %v", err)
+	}
+	creds.tokenURL = server.URL
+
+	if token, err := creds.Token(); err != nil || token != "app-token" {
+		t.Errorf("Token() = %q, %v", token, err)
+	}
+}
+
+// TestParseCertificatePEM verifies that incomplete PEM files are rejected
+func TestParseCertificatePEM(t *testing.T) {
+	pemFile, _ := writeTestCertificate(t)
+	data, err := os.ReadFile(pemFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certOnly := data[strings.Index(string(data), "-----BEGIN CERTIFICATE"):]
+	keyOnly := data[:strings.Index(string(data), "-----BEGIN CERTIFICATE")]
+
+	for name, input := range map[string][]byte{"cert only": certOnly, "key only": keyOnly, "empty": nil} {
+		if _, _, err := parseCertificatePEM(input); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
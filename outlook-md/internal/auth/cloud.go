@@ -0,0 +1,132 @@
+package auth
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// Cloud holds the hosts of the Microsoft cloud a tenant lives in
+type Cloud struct {
+	AuthorityHost string // Microsoft identity platform, e.g. https://login.microsoftonline.com
+	GraphHost     string // Microsoft Graph, e.g. https://graph.microsoft.com
+}
+
+// Clouds are the presets for the global service and the national clouds
+var Clouds = map[string]Cloud{
+	"global": {AuthorityHost: "https://login.microsoftonline.com", GraphHost: "https://graph.microsoft.com"},
+	"usgov":  {AuthorityHost: "https://login.microsoftonline.us", GraphHost: "https://graph.microsoft.us"}, // GCC High
+	"dod":    {AuthorityHost: "https://login.microsoftonline.us", GraphHost: "https://dod-graph.microsoft.us"},
+	"china":  {AuthorityHost: "https://login.chinacloudapi.cn", GraphHost: "https://microsoftgraph.chinacloudapi.cn"}, // Operated by 21Vianet
+}
+
+// GlobalCloud is the worldwide Microsoft 365 service
+var GlobalCloud = Clouds["global"]
+
+// oidcScopes are requested as is in every cloud; other scopes are Graph
+// permissions
+var oidcScopes = map[string]bool{"openid": true, "profile": true, "offline_access": true}
+
+// ResolveCloud returns the named preset ("global" if empty), with its hosts
+// replaced by authorityHost and graphHost if set
+func ResolveCloud(name, authorityHost, graphHost string) (Cloud, error) {
+	if name == "" {
+		name = "global"
+	}
+	cloud, ok := Clouds[name]
+	if !ok {
+		names := make([]string, 0, len(Clouds))
+		for n := range Clouds {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return Cloud{}, fmt.Errorf("unknown cloud %q (supported: %s)", name, strings.Join(names, ", "))
+	}
+
+	var err error
+	if authorityHost != "" {
+		if cloud.AuthorityHost, err = parseHost(authorityHost); err != nil {
+			return Cloud{}, fmt.Errorf("invalid authority host: %w", err)
+		}
+	}
+	if graphHost != "" {
+		if cloud.GraphHost, err = parseHost(graphHost); err != nil {
+			return Cloud{}, fmt.Errorf("invalid Graph host: %w", err)
+		}
+	}
+	return cloud, nil
+}
+
+// parseHost validates a host URL, allowing plain HTTP only for loopback
+// addresses (local stand-in servers), and strips any trailing slash
+func parseHost(raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("%q is not an absolute URL", raw)
+	}
+	switch u.Scheme {
+	case "https":
+	case "http":
+		if !isLoopback(u.Hostname()) {
+			return "", fmt.Errorf("%q must use https", raw)
+		}
+	default:
+		return "", fmt.Errorf("%q must use https", raw)
+	}
+	return strings.TrimSuffix(raw, "/"), nil
+}
+
+// isLoopback reports whether host is localhost or a loopback IP address
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// Endpoint returns the OAuth2 endpoints of a tenant ("common" if empty)
+func (c Cloud) Endpoint(tenantID string) oauth2.Endpoint {
+	if tenantID == "" {
+		tenantID = "common"
+	}
+	base := c.AuthorityHost + "/" + tenantID + "/oauth2/v2.0"
+	return oauth2.Endpoint{
+		AuthURL:       base + "/authorize",
+		TokenURL:      base + "/token",
+		DeviceAuthURL: base + "/devicecode",
+	}
+}
+
+// GraphURL returns the base URL of the Graph v1.0 API
+func (c Cloud) GraphURL() string {
+	return c.GraphHost + "/v1.0"
+}
+
+// Scopes returns the delegated scopes to request. Outside the global
+// service, Graph permissions are qualified with the cloud's Graph host, as
+// unqualified scopes refer to graph.microsoft.com.
+func (c Cloud) Scopes() []string {
+	if c.GraphHost == GlobalCloud.GraphHost {
+		return Scopes
+	}
+	scopes := make([]string, len(Scopes))
+	for i, scope := range Scopes {
+		if oidcScopes[scope] {
+			scopes[i] = scope
+		} else {
+			scopes[i] = c.GraphHost + "/" + scope
+		}
+	}
+	return scopes
+}
+
+// AppScopes returns the scope requesting the application permissions granted
+// to the app registration (e.g. Calendars.Read) for app-only access
+func (c Cloud) AppScopes() []string {
+	return []string{c.GraphHost + "/.default"}
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestResolveCloud verifies the presets and free-form hosts
+func TestResolveCloud(t *testing.T) {
+	tests := []struct {
+		name, authority, graph string
+		want                   Cloud
+	}{
+		{"", "", "", GlobalCloud},
+		{"usgov", "", "", Cloud{"https://login.microsoftonline.us", "https://graph.microsoft.us"}},
+		{"dod", "", "", Cloud{"https://login.microsoftonline.us", "https://dod-graph.microsoft.us"}},
+		{"china", "", "", Cloud{"https://login.chinacloudapi.cn", "https://microsoftgraph.chinacloudapi.cn"}},
+		{"", "https://login.example.com/", "http://127.0.0.1:8080", Cloud{"https://login.example.com", "http://127.0.0.1:8080"}},
+		{"usgov", "", "http://localhost:9000/graph", Cloud{"https://login.microsoftonline.us", "http://localhost:9000/graph"}},
+	}
+	for _, tt := range tests {
+		got, err := ResolveCloud(tt.name, tt.authority, tt.graph)
+		if err != nil || got != tt.want {
+			t.Errorf("ResolveCloud(%q, %q, %q) = %+v, %v, want %+v", tt.name, tt.authority, tt.graph, got, err, tt.want)
+		}
+	}
+
+	for _, bad := range [][3]string{
+		{"mars", "", ""},
+		{"", "login.example.com", ""},
+		{"", "", "http://graph.example.com"},
+		{"", "ftp://login.example.com", ""},
+		{"", "https://login.example.com/?tenant=x", ""},
+	} {
+		if _, err := ResolveCloud(bad[0], bad[1], bad[2]); err == nil {
+			t.Errorf("ResolveCloud(%q, %q, %q): expected error", bad[0], bad[1], bad[2])
+		}
+	}
+}
+
+// TestCloudEndpoints verifies the OAuth2 endpoints, Graph URL and scopes
+func TestCloudEndpoints(t *testing.T) {
+	cloud := Clouds["china"]
+	endpoint := cloud.Endpoint("tenant")
+	if endpoint.TokenURL != "https://login.chinacloudapi.cn/tenant/oauth2/v2.0/token" ||
+		endpoint.DeviceAuthURL != "https://login.chinacloudapi.cn/tenant/oauth2/v2.0/devicecode" ||
+		endpoint.AuthURL != "https://login.chinacloudapi.cn/tenant/oauth2/v2.0/authorize" {
+		t.Errorf("Unexpected endpoint: %+v", endpoint)
+	}
+	if got := GlobalCloud.Endpoint("").TokenURL; got != "https://login.microsoftonline.com/common/oauth2/v2.0/token" {
+		t.Errorf("Expected common tenant by default, got %s", got)
+	}
+	if got := cloud.GraphURL(); got != "https://microsoftgraph.chinacloudapi.cn/v1.0" {
+		t.Errorf("Unexpected Graph URL %s", got)
+	}
+
+	if !reflect.DeepEqual(GlobalCloud.Scopes(), Scopes) {
+		t.Errorf("Expected unqualified scopes in the global cloud, got %v", GlobalCloud.Scopes())
+	}
+	want := []string{"openid", "profile", "https://microsoftgraph.chinacloudapi.cn/Calendars.Read", "https://microsoftgraph.chinacloudapi.cn/Calendars.Read.Shared", "offline_access"}
+	if got := cloud.Scopes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Scopes() = %v, want %v", got, want)
+	}
+	if got := cloud.AppScopes(); !reflect.DeepEqual(got, []string{"https://microsoftgraph.chinacloudapi.cn/.default"}) {
+		t.Errorf("Unexpected app scopes %v", got)
+	}
+}
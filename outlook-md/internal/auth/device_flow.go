@@ -6,37 +6,41 @@ import (
 	"os"
 
 	"golang.org/x/oauth2"
-	"golang.org/x/oauth2/microsoft"
 )
 
+// Scopes are the Microsoft Graph scopes requested for delegated access
+// (qualified with the Graph host by Cloud.Scopes in national clouds)
+var Scopes = []string{
+	"openid",  // id_token describing the account (auth status)
+	"profile", // Name and username claims in the id_token
+	"Calendars.Read",
+	"Calendars.Read.Shared", // Calendars shared with or delegated to the user (--user)
+	"offline_access",
+}
+
 // DeviceCodeAuthenticator handles OAuth2 device code flow
 type DeviceCodeAuthenticator struct {
 	clientID string
-	tenantID string
 	scopes   []string
+	endpoint oauth2.Endpoint
 }
 
-// NewDeviceCodeAuthenticator creates a new device code authenticator
-func NewDeviceCodeAuthenticator(clientID, tenantID string) *DeviceCodeAuthenticator {
+// NewDeviceCodeAuthenticator creates a new device code authenticator for a
+// tenant in the given cloud
+func NewDeviceCodeAuthenticator(clientID, tenantID string, cloud Cloud) *DeviceCodeAuthenticator {
 	return &DeviceCodeAuthenticator{
 		clientID: clientID,
-		tenantID: tenantID,
-		scopes: []string{
-			"Calendars.Read",
-			"offline_access",
-		},
+		scopes:   cloud.Scopes(),
+		endpoint: cloud.Endpoint(tenantID),
 	}
 }
 
 // Authenticate performs device code flow and returns a token
 func (a *DeviceCodeAuthenticator) Authenticate(ctx context.Context) (*oauth2.Token, error) {
-	// Configure OAuth2 endpoint for Microsoft
-	endpoint := microsoft.AzureADEndpoint(a.tenantID)
-
 	config := &oauth2.Config{
 		ClientID: a.clientID,
 		Scopes:   a.scopes,
-		Endpoint: endpoint,
+		Endpoint: a.endpoint,
 	}
 
 	// Initiate device code flow
@@ -70,16 +74,11 @@ type TokenSource struct {
 }
 
 // NewTokenSource creates a token source with automatic refresh
-func NewTokenSource(token *oauth2.Token, clientID, tenantID string, cache *TokenCache) *TokenSource {
-	endpoint := microsoft.AzureADEndpoint(tenantID)
-
+func NewTokenSource(token *oauth2.Token, clientID, tenantID string, cloud Cloud, cache *TokenCache) *TokenSource {
 	config := &oauth2.Config{
 		ClientID: clientID,
-		Scopes: []string{
-			"Calendars.Read",
-			"offline_access",
-		},
-		Endpoint: endpoint,
+		Scopes:   cloud.Scopes(),
+		Endpoint: cloud.Endpoint(tenantID),
 	}
 
 	return &TokenSource{
@@ -96,9 +95,22 @@ func (ts *TokenSource) Token() (*oauth2.Token, error) {
 		return ts.token, nil
 	}
 
-	// Refresh token
+	return ts.refresh(ts.token)
+}
+
+// ForceRefresh redeems the refresh token for a new access token even if the
+// current one has not expired (e.g. after Graph rejected it)
+func (ts *TokenSource) ForceRefresh() (*oauth2.Token, error) {
+	if ts.token.RefreshToken == "" {
+		return nil, fmt.Errorf("failed to refresh token: no refresh token")
+	}
+	return ts.refresh(&oauth2.Token{RefreshToken: ts.token.RefreshToken})
+}
+
+// refresh exchanges the refresh token of t and caches the new token
+func (ts *TokenSource) refresh(t *oauth2.Token) (*oauth2.Token, error) {
 	ctx := context.Background()
-	newToken, err := ts.config.TokenSource(ctx, ts.token).Token()
+	newToken, err := ts.config.TokenSource(ctx, t).Token()
 	if err != nil {
 		return nil, fmt.Errorf("failed to refresh token: %w", err)
 	}
@@ -152,3 +152,23 @@ func TestTokenExpiredNeedsRefresh(t *testing.T) {
 	// which is beyond the scope of Phase 5. We're verifying
 	// the logic detects expiration correctly.
 }
+
+// TestScopes verifies the delegated scopes requested by the device flow and refresh
+func TestScopes(t *testing.T) {
+	want := map[string]bool{"openid": true, "profile": true, "Calendars.Read": true, "Calendars.Read.Shared": true, "offline_access": true}
+	if len(Scopes) != len(want) {
+		t.Fatalf("Expected %d scopes, got %v", len(want), Scopes)
+	}
+	for _, scope := range Scopes {
+		if !want[scope] {
+			t.Errorf("Unexpected scope %q", scope)
+		}
+	}
+
+	if got := NewDeviceCodeAuthenticator("client", "tenant", GlobalCloud).scopes; len(got) != len(Scopes) {
+		t.Errorf("Device flow requests %v, want %v", got, Scopes)
+	}
+	if got := NewTokenSource(&oauth2.Token{}, "client", "tenant", GlobalCloud, nil).config.Scopes; len(got) != len(Scopes) {
+		t.Errorf("Token refresh requests %v, want %v", got, Scopes)
+	}
+}
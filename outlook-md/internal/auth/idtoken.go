@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// idTokenClaims are the id_token claims describing the signed-in account
+type idTokenClaims struct {
+	Username string `json:"preferred_username"`
+	Name     string `json:"name"`
+	TenantID string `json:"tid"`
+}
+
+// parseIDToken decodes the claims of an id_token. The signature is not
+// verified: the token came straight from the token endpoint over TLS and is
+// only used to describe the account.
+func parseIDToken(raw string) (*idTokenClaims, error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid id_token: expected 3 parts, got %d", len(parts))
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("invalid id_token payload: %w", err)
+	}
+
+	var claims idTokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("invalid id_token claims: %w", err)
+	}
+	return &claims, nil
+}
@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"golang.org/x/oauth2"
+)
+
+// Authenticator signs the user in interactively
+// (DeviceCodeAuthenticator or AuthCodeAuthenticator)
+type Authenticator interface {
+	Authenticate(ctx context.Context) (*oauth2.Token, error)
+}
+
+// Session provides Graph access tokens from the token cache, refreshing them
+// as needed and signing in interactively only when the cached token cannot
+// be refreshed
+type Session struct {
+	clientID string
+	tenantID string
+	cloud    Cloud
+	cache    *TokenCache
+	source   *TokenSource
+
+	// signIn obtains a new token interactively
+	signIn func(ctx context.Context) (*oauth2.Token, error)
+}
+
+// NewSession creates a session for the given app registration, cloud and
+// token cache, signing in with the given authenticator
+func NewSession(clientID, tenantID string, cloud Cloud, cache *TokenCache, authenticator Authenticator) *Session {
+	return &Session{
+		clientID: clientID,
+		tenantID: tenantID,
+		cloud:    cloud,
+		cache:    cache,
+		signIn:   authenticator.Authenticate,
+	}
+}
+
+// Token returns a valid access token, loading it from the cache, refreshing
+// it or signing in as needed
+func (s *Session) Token() (string, error) {
+	if s.source == nil {
+		token, err := s.cache.Load()
+		if err != nil && !os.IsNotExist(err) {
+			// Unexpected error loading cache (not just "file not found")
+			return "", fmt.Errorf("failed to load token cache: %w", err)
+		}
+		if err == nil {
+			s.source = NewTokenSource(token, s.clientID, s.tenantID, s.cloud, s.cache)
+		}
+	}
+
+	if s.source != nil {
+		token, err := s.source.Token()
+		if err == nil {
+			return token.AccessToken, nil
+		}
+		// Token refresh failed, need to re-authenticate
+		fmt.Fprintf(os.Stderr, "Warning: Failed to refresh token: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Re-authenticating...\n\n")
+	}
+
+	return s.authenticate()
+}
+
+// Refresh obtains a new access token after Graph rejected the current one,
+// signing in again if the refresh token is no longer accepted
+func (s *Session) Refresh() (string, error) {
+	if s.source != nil {
+		token, err := s.source.ForceRefresh()
+		if err == nil {
+			return token.AccessToken, nil
+		}
+		fmt.Fprintf(os.Stderr, "Warning: Failed to refresh rejected token: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Re-authenticating...\n\n")
+	}
+
+	return s.authenticate()
+}
+
+// Cache returns the session's token cache
+func (s *Session) Cache() *TokenCache {
+	return s.cache
+}
+
+// DisableSignIn makes the session fail instead of signing in interactively
+// when the cached token cannot be used or refreshed
+func (s *Session) DisableSignIn() {
+	s.signIn = func(ctx context.Context) (*oauth2.Token, error) {
+		return nil, fmt.Errorf("not signed in (run 'outlook-md auth login')")
+	}
+}
+
+// Login signs in interactively even if a cached token is still valid
+func (s *Session) Login() error {
+	_, err := s.authenticate()
+	return err
+}
+
+// authenticate signs in interactively and caches the new token
+func (s *Session) authenticate() (string, error) {
+	token, err := s.signIn(context.Background())
+	if err != nil {
+		return "", fmt.Errorf("sign-in failed: %w", err)
+	}
+
+	if err := s.cache.Save(token); err != nil {
+		// Log warning but don't fail - we have a valid token
+		fmt.Fprintf(os.Stderr, "Warning: Failed to save token to cache: %v\n", err)
+	}
+	s.source = NewTokenSource(token, s.clientID, s.tenantID, s.cloud, s.cache)
+
+	return token.AccessToken, nil
+}
@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// newTestSession returns a session whose sign-in hands out "signed-in" tokens
+func newTestSession(t *testing.T) (*Session, *int) {
+	t.Helper()
+	signIns := 0
+	s := NewSession("client", "tenant", GlobalCloud, NewTokenCache(filepath.Join(t.TempDir(), "token.json")), NewDeviceCodeAuthenticator("client", "tenant", GlobalCloud))
+	s.signIn = func(ctx context.Context) (*oauth2.Token, error) {
+		signIns++
+		return &oauth2.Token{AccessToken: "signed-in", RefreshToken: "refresh-2", Expiry: time.Now().Add(time.Hour)}, nil
+	}
+	return s, &signIns
+}
+
+// withTokenEndpoint points the session's token source at a fake token endpoint
+func withTokenEndpoint(s *Session, token *oauth2.Token, url string) {
+	s.source = &TokenSource{
+		token:  token,
+		config: &oauth2.Config{ClientID: "client", Endpoint: oauth2.Endpoint{TokenURL: url, AuthStyle: oauth2.AuthStyleInParams}},
+		cache:  s.cache,
+	}
+}
+
+// TestSessionToken verifies that cached tokens are used and sign-in is the fallback
+func TestSessionToken(t *testing.T) {
+	s, signIns := newTestSession(t)
+
+	// No cached token: sign in and cache the result
+	token, err := s.Token()
+	if err != nil || token != "signed-in" || *signIns != 1 {
+		t.Fatalf("Expected sign-in without cached token, got %q, %v (%d sign-ins)", token, err, *signIns)
+	}
+	if cached, err := s.cache.Load(); err != nil || cached.AccessToken != "signed-in" {
+		t.Errorf("Expected signed-in token to be cached, got %+v, %v", cached, err)
+	}
+
+	// A valid cached token is used as is
+	s2 := NewSession("client", "tenant", GlobalCloud, s.cache, NewDeviceCodeAuthenticator("client", "tenant", GlobalCloud))
+	s2.signIn = s.signIn
+	if token, err := s2.Token(); err != nil || token != "signed-in" || *signIns != 1 {
+		t.Errorf("Expected cached token without sign-in, got %q, %v (%d sign-ins)", token, err, *signIns)
+	}
+}
+
+// TestSessionRefresh verifies that a rejected token is refreshed even if not expired
+func TestSessionRefresh(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("grant_type") != "refresh_token" || r.Form.Get("refresh_token") != "refresh-1" {
+			t.Errorf("Unexpected token request: %v", r.Form)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "refreshed", "token_type": "Bearer", "expires_in": 3600}`))
+	}))
+	defer server.Close()
+
+	s, signIns := newTestSession(t)
+	withTokenEndpoint(s, &oauth2.Token{AccessToken: "rejected", RefreshToken: "refresh-1", Expiry: time.Now().Add(time.Hour)}, server.URL)
+
+	token, err := s.Refresh()
+	if err != nil || token != "refreshed" {
+		t.Fatalf("Expected refreshed token, got %q, %v", token, err)
+	}
+	if *signIns != 0 {
+		t.Errorf("Expected no sign-in, got %d", *signIns)
+	}
+
+	cached, err := s.cache.Load()
+	if err != nil || cached.AccessToken != "refreshed" || cached.RefreshToken != "refresh-1" {
+		t.Errorf("Expected refreshed token cached with its refresh token, got %+v, %v", cached, err)
+	}
+}
+
+// TestSessionRefreshFallsBackToSignIn verifies the device flow fallback
+func TestSessionRefreshFallsBackToSignIn(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": "invalid_grant", "error_description": "The refresh token has been revoked."}`))
+	}))
+	defer server.Close()
+
+	s, signIns := newTestSession(t)
+	withTokenEndpoint(s, &oauth2.Token{AccessToken: "rejected", RefreshToken: "revoked", Expiry: time.Now().Add(time.Hour)}, server.URL)
+
+	token, err := s.Refresh()
+	if err != nil || token != "signed-in" || *signIns != 1 {
+		t.Fatalf("Expected sign-in after failed refresh, got %q, %v (%d sign-ins)", token, err, *signIns)
+	}
+
+	s.signIn = func(ctx context.Context) (*oauth2.Token, error) {
+		return nil, errors.New("user cancelled")
+	}
+	s.source = nil
+	s.cache = NewTokenCache(filepath.Join(t.TempDir(), "token.json"))
+	if _, err := s.Refresh(); err == nil {
+		t.Error("Expected error when sign-in fails")
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"golang.org/x/oauth2"
 )
@@ -13,6 +15,26 @@ type TokenCache struct {
 	filePath string
 }
 
+// cachedToken is the content of the cache file: the OAuth2 token plus the
+// parts of the token response that describe the sign-in
+type cachedToken struct {
+	*oauth2.Token
+	IDToken              string     `json:"id_token,omitempty"`
+	Scope                string     `json:"scope,omitempty"`
+	RefreshTokenIssuedAt *time.Time `json:"refresh_token_issued_at,omitempty"`
+}
+
+// TokenInfo describes the cached sign-in (see TokenCache.Info)
+type TokenInfo struct {
+	Username             string    // User principal name from the id_token
+	Name                 string    // Display name from the id_token
+	TenantID             string    // Directory (tenant) ID from the id_token
+	Scopes               []string  // Scopes granted to the access token
+	Expiry               time.Time // Access token expiry
+	HasRefreshToken      bool
+	RefreshTokenIssuedAt time.Time // Zero if unknown (cached by an older version)
+}
+
 // NewTokenCache creates a new token cache with the specified file path
 func NewTokenCache(filePath string) *TokenCache {
 	return &TokenCache{
@@ -20,10 +42,40 @@ func NewTokenCache(filePath string) *TokenCache {
 	}
 }
 
-// Save writes the token to the cache file with 0600 permissions
+// Path returns the location of the cache file
+func (tc *TokenCache) Path() string {
+	return tc.filePath
+}
+
+// Save writes the token to the cache file with 0600 permissions. The
+// id_token and granted scopes are kept from the previous token if the
+// token endpoint did not return them again.
 func (tc *TokenCache) Save(token *oauth2.Token) error {
+	entry := cachedToken{
+		Token:   token,
+		IDToken: extraString(token, "id_token"),
+		Scope:   extraString(token, "scope"),
+	}
+	if token.RefreshToken != "" {
+		issued := time.Now().UTC().Truncate(time.Second)
+		entry.RefreshTokenIssuedAt = &issued
+	}
+
+	if prev, err := tc.load(); err == nil {
+		if entry.IDToken == "" {
+			entry.IDToken = prev.IDToken
+		}
+		if entry.Scope == "" {
+			entry.Scope = prev.Scope
+		}
+		// Refreshing keeps the refresh token unless a new one is issued
+		if token.RefreshToken != "" && token.RefreshToken == prev.RefreshToken {
+			entry.RefreshTokenIssuedAt = prev.RefreshTokenIssuedAt
+		}
+	}
+
 	// Marshal token to JSON
-	data, err := json.MarshalIndent(token, "", "  ")
+	data, err := json.MarshalIndent(entry, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal token: %w", err)
 	}
@@ -39,6 +91,50 @@ func (tc *TokenCache) Save(token *oauth2.Token) error {
 
 // Load reads the token from the cache file
 func (tc *TokenCache) Load() (*oauth2.Token, error) {
+	entry, err := tc.load()
+	if err != nil {
+		return nil, err
+	}
+	return entry.Token, nil
+}
+
+// Info describes the cached sign-in from the cached token and id_token
+func (tc *TokenCache) Info() (*TokenInfo, error) {
+	entry, err := tc.load()
+	if err != nil {
+		return nil, err
+	}
+
+	info := &TokenInfo{
+		Scopes:          strings.Fields(entry.Scope),
+		Expiry:          entry.Expiry,
+		HasRefreshToken: entry.RefreshToken != "",
+	}
+	if entry.RefreshTokenIssuedAt != nil {
+		info.RefreshTokenIssuedAt = *entry.RefreshTokenIssuedAt
+	}
+	if entry.IDToken != "" {
+		claims, err := parseIDToken(entry.IDToken)
+		if err != nil {
+			return nil, err
+		}
+		info.Username = claims.Username
+		info.Name = claims.Name
+		info.TenantID = claims.TenantID
+	}
+	return info, nil
+}
+
+// Delete removes the cache file; a missing file is not an error
+func (tc *TokenCache) Delete() error {
+	if err := os.Remove(tc.filePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete token cache: %w", err)
+	}
+	return nil
+}
+
+// load reads and parses the cache file
+func (tc *TokenCache) load() (*cachedToken, error) {
 	// Read file contents
 	data, err := os.ReadFile(tc.filePath)
 	if err != nil {
@@ -46,11 +142,20 @@ func (tc *TokenCache) Load() (*oauth2.Token, error) {
 	}
 
 	// Unmarshal JSON to token
-	var token oauth2.Token
-	err = json.Unmarshal(data, &token)
+	var entry cachedToken
+	err = json.Unmarshal(data, &entry)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal token: %w", err)
 	}
+	if entry.Token == nil {
+		entry.Token = &oauth2.Token{}
+	}
+
+	return &entry, nil
+}
 
-	return &token, nil
+// extraString returns a string field of the token response, if present
+func extraString(token *oauth2.Token, key string) string {
+	s, _ := token.Extra(key).(string)
+	return s
 }
@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// testIDToken builds an unsigned id_token with the given claims
+func testIDToken(claims string) string {
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(`{"alg":"none"}`)) + "." + enc.EncodeToString([]byte(claims)) + ".sig"
+}
+
+// TestTokenCacheInfo verifies that the id_token and scopes describe the sign-in
+func TestTokenCacheInfo(t *testing.T) {
+	cache := NewTokenCache(filepath.Join(t.TempDir(), "token.json"))
+	expiry := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	token := (&oauth2.Token{AccessToken: "access-1", RefreshToken: "refresh-1", Expiry: expiry}).WithExtra(map[string]interface{}{
+		"id_token": testIDToken(`{"preferred_username":"alice@contoso.com","name":"Alice Smith","tid":"tenant-123"}`),
+		"scope":    "openid profile Calendars.Read",
+	})
+	if err := cache.Save(token); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	info, err := cache.Info()
+	if err != nil {
+		t.Fatalf("Info failed: %v", err)
+	}
+	if info.Username != "alice@contoso.com" || info.Name != "Alice Smith" || info.TenantID != "tenant-123" {
+		t.Errorf("Unexpected account: %+v", info)
+	}
+	if !reflect.DeepEqual(info.Scopes, []string{"openid", "profile", "Calendars.Read"}) {
+		t.Errorf("Unexpected scopes: %q", info.Scopes)
+	}
+	if !info.Expiry.Equal(expiry) || !info.HasRefreshToken || info.RefreshTokenIssuedAt.IsZero() {
+		t.Errorf("Unexpected token details: %+v", info)
+	}
+	issued := info.RefreshTokenIssuedAt
+
+	// A refresh that returns neither id_token nor a new refresh token keeps both
+	if err := cache.Save(&oauth2.Token{AccessToken: "access-2", RefreshToken: "refresh-1", Expiry: expiry}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	info, err = cache.Info()
+	if err != nil {
+		t.Fatalf("Info failed: %v", err)
+	}
+	if info.Username != "alice@contoso.com" || len(info.Scopes) != 3 || !info.RefreshTokenIssuedAt.Equal(issued) {
+		t.Errorf("Expected sign-in details to be kept, got %+v", info)
+	}
+}
+
+// TestTokenCacheLegacyFormat verifies that caches written before id_tokens
+// were stored still load
+func TestTokenCacheLegacyFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token.json")
+	legacy := `{"access_token": "old", "token_type": "Bearer", "refresh_token": "old-refresh", "expiry": "2026-01-01T00:00:00Z"}`
+	if err := os.WriteFile(path, []byte(legacy), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cache := NewTokenCache(path)
+	token, err := cache.Load()
+	if err != nil || token.AccessToken != "old" || token.RefreshToken != "old-refresh" {
+		t.Fatalf("Expected legacy token to load, got %+v, %v", token, err)
+	}
+	info, err := cache.Info()
+	if err != nil || info.Username != "" || !info.HasRefreshToken || !info.RefreshTokenIssuedAt.IsZero() {
+		t.Errorf("Unexpected info for legacy token: %+v, %v", info, err)
+	}
+}
+
+// TestTokenCacheDelete verifies that deleting is idempotent
+func TestTokenCacheDelete(t *testing.T) {
+	cache := NewTokenCache(filepath.Join(t.TempDir(), "token.json"))
+	if err := cache.Save(&oauth2.Token{AccessToken: "access"}); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := cache.Delete(); err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+	}
+	if _, err := cache.Load(); !os.IsNotExist(err) {
+		t.Errorf("Expected cache to be gone, got %v", err)
+	}
+}
+
+// TestParseIDToken verifies id_token decoding errors
+func TestParseIDToken(t *testing.T) {
+	for _, raw := range []string{"not-a-jwt", "a.!!!.c", "a." + base64.RawURLEncoding.EncodeToString([]byte("[]")) + ".c"} {
+		if _, err := parseIDToken(raw); err == nil {
+			t.Errorf("Expected error for %q", raw)
+		}
+	}
+}
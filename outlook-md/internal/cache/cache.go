@@ -0,0 +1,115 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+// EventCache stores the output of successful fetches on disk so that the last
+// known events can be served when Graph is unreachable
+type EventCache struct {
+	dir string
+}
+
+// NewEventCache creates an event cache storing entries in dir
+func NewEventCache(dir string) *EventCache {
+	return &EventCache{
+		dir: dir,
+	}
+}
+
+// Key identifies a cache entry from the parts of a request that affect its
+// result (window, timezone, calendars and filters)
+func Key(parts ...string) string {
+	h := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(h[:])[:32]
+}
+
+// path returns the file holding the entry for key
+func (c *EventCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// Save atomically writes the output for key with 0600 permissions
+func (c *EventCache) Save(key string, output *schema.CLIOutput) error {
+	data, err := json.Marshal(output)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(c.dir, ".cache-*")
+	if err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	return nil
+}
+
+// Load reads the output cached for key and marks it stale. Changes recorded
+// by a --delta fetch are cleared since they are relative to that fetch.
+func (c *EventCache) Load(key string) (*schema.CLIOutput, error) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, err // Return raw error so caller can check os.IsNotExist
+	}
+
+	var output schema.CLIOutput
+	if err := json.Unmarshal(data, &output); err != nil {
+		return nil, fmt.Errorf("failed to parse cache entry: %w", err)
+	}
+
+	output.Stale = true
+	output.Removed = nil
+	for i := range output.Events {
+		output.Events[i].Change = ""
+	}
+	return &output, nil
+}
+
+// Prune removes entries not updated within maxAge
+func (c *EventCache) Prune(maxAge time.Duration) error {
+	entries, err := os.ReadDir(c.dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	cutoff := time.Now().Add(-maxAge)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if info.ModTime().Before(cutoff) {
+			os.Remove(filepath.Join(c.dir, entry.Name()))
+		}
+	}
+	return nil
+}
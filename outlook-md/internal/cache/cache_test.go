@@ -0,0 +1,99 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+// TestEventCacheRoundTrip verifies that cached output is served stale without delta marks
+func TestEventCacheRoundTrip(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+	c := NewEventCache(dir)
+	key := Key("UTC", "2026-07-01T00:00:00Z", "2026-07-02T00:00:00Z")
+
+	if _, err := c.Load(key); !os.IsNotExist(err) {
+		t.Fatalf("Expected not-exist error for missing entry, got %v", err)
+	}
+
+	fetchedAt := time.Date(2026, 7, 1, 8, 0, 0, 0, time.UTC)
+	output := &schema.CLIOutput{
+		Version:   1,
+		Timezone:  "UTC",
+		Events:    []schema.CalendarEvent{{ID: "EVENT-1", Subject: "Standup", Change: schema.ChangeUpdated}},
+		Removed:   []schema.RemovedEvent{{ID: "EVENT-2", Subject: "Planning"}},
+		FetchedAt: &fetchedAt,
+	}
+	if err := c.Save(key, output); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	info, err := os.Stat(filepath.Join(dir, key+".json"))
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected 0600 permissions, got %v", info.Mode().Perm())
+	}
+
+	cached, err := c.Load(key)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !cached.Stale {
+		t.Error("Expected cached output to be marked stale")
+	}
+	if cached.FetchedAt == nil || !cached.FetchedAt.Equal(fetchedAt) {
+		t.Errorf("Expected fetch time %v, got %v", fetchedAt, cached.FetchedAt)
+	}
+	if len(cached.Events) != 1 || cached.Events[0].Subject != "Standup" || cached.Events[0].Change != "" {
+		t.Errorf("Expected cached event without change mark, got %+v", cached.Events)
+	}
+	if cached.Removed != nil {
+		t.Errorf("Expected removed events to be cleared, got %+v", cached.Removed)
+	}
+}
+
+// TestKey verifies that keys depend on every part and their boundaries
+func TestKey(t *testing.T) {
+	key := Key("UTC", "calendar=Work")
+	if len(key) != 32 {
+		t.Errorf("Expected 32 character key, got %q", key)
+	}
+	if key != Key("UTC", "calendar=Work") {
+		t.Error("Expected keys to be deterministic")
+	}
+	if key == Key("UTC", "calendar=Home") || key == Key("UTCcalendar=Work") {
+		t.Error("Expected different parts to give different keys")
+	}
+}
+
+// TestPrune verifies that only stale entries are removed
+func TestPrune(t *testing.T) {
+	dir := t.TempDir()
+	c := NewEventCache(dir)
+	stale := filepath.Join(dir, "stale.json")
+	fresh := filepath.Join(dir, "fresh.json")
+	for _, path := range []string{stale, fresh} {
+		if err := os.WriteFile(path, []byte("{}"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(stale, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Prune(24 * time.Hour); err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Error("Expected stale entry to be removed")
+	}
+	if _, err := os.Stat(fresh); err != nil {
+		t.Error("Expected fresh entry to be kept")
+	}
+}
@@ -0,0 +1,154 @@
+package calendar
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+// Source identifies a calendar to read events from
+type Source struct {
+	User       string // Mailbox (UPN) of another user; empty for the signed-in user
+	CalendarID string // Calendar ID; empty for the mailbox's default calendar
+	Name       string // Display name used to tag events
+}
+
+// calendarViewPath returns the Graph path of the source's calendar view
+func (s Source) calendarViewPath() string {
+	mailbox := s.mailboxPath()
+	if s.CalendarID == "" {
+		return mailbox + "/calendarView"
+	}
+	return mailbox + "/calendars/" + url.PathEscape(s.CalendarID) + "/calendarView"
+}
+
+// mailboxPath returns the Graph path of the source's mailbox
+func (s Source) mailboxPath() string {
+	if s.User != "" {
+		return "/users/" + url.PathEscape(s.User)
+	}
+	return "/me"
+}
+
+// eventPath returns the Graph path of an event in the source's mailbox;
+// event IDs are unique across the calendars of a mailbox
+func (s Source) eventPath(id string) string {
+	return s.mailboxPath() + "/events/" + url.PathEscape(id)
+}
+
+// ref returns the tag for events read from the source
+// (nil for the signed-in user's default calendar)
+func (s Source) ref() *schema.CalendarRef {
+	if s.User == "" && s.CalendarID == "" {
+		return nil
+	}
+	return &schema.CalendarRef{ID: s.CalendarID, Name: s.Name, Owner: s.User}
+}
+
+// graphCalendarsResponse represents a page of /me/calendars
+type graphCalendarsResponse struct {
+	Value    []graphCalendar `json:"value"`
+	NextLink string          `json:"@odata.nextLink"`
+}
+
+// graphCalendar represents a calendar from Microsoft Graph API
+type graphCalendar struct {
+	ID                string `json:"id"`
+	Name              string `json:"name"`
+	Color             string `json:"color"`    // e.g. "lightBlue", "auto"
+	HexColor          string `json:"hexColor"` // e.g. "#E07A5F", empty if not set
+	CanEdit           bool   `json:"canEdit"`
+	IsDefaultCalendar bool   `json:"isDefaultCalendar"`
+	Owner             struct {
+		Name    string `json:"name"`
+		Address string `json:"address"`
+	} `json:"owner"`
+}
+
+// ListCalendars implements the GraphClient interface
+func (c *graphClientImpl) ListCalendars(ctx context.Context) ([]schema.Calendar, error) {
+	ctx = c.withRetryDeadline(ctx)
+	calendars := []schema.Calendar{}
+
+	nextURL := c.baseURL + "/me/calendars?$top=100"
+	for nextURL != "" {
+		var page graphCalendarsResponse
+		if err := c.get(ctx, nextURL, nil, &page); err != nil {
+			return nil, err
+		}
+
+		for _, gc := range page.Value {
+			color := gc.HexColor
+			if color == "" {
+				color = gc.Color
+			}
+			calendars = append(calendars, schema.Calendar{
+				ID:        gc.ID,
+				Name:      gc.Name,
+				Color:     color,
+				Owner:     schema.Organizer{Name: gc.Owner.Name, Email: gc.Owner.Address},
+				CanEdit:   gc.CanEdit,
+				IsDefault: gc.IsDefaultCalendar,
+			})
+		}
+
+		nextURL = page.NextLink
+	}
+
+	return calendars, nil
+}
+
+// FindCalendar looks up a calendar by ID or, case-insensitively, by name
+func FindCalendar(calendars []schema.Calendar, nameOrID string) (schema.Calendar, bool) {
+	for _, cal := range calendars {
+		if cal.ID == nameOrID {
+			return cal, true
+		}
+	}
+	for _, cal := range calendars {
+		if strings.EqualFold(cal.Name, nameOrID) {
+			return cal, true
+		}
+	}
+	return schema.Calendar{}, false
+}
+
+// MergeEvents merges events from several calendars, keeping the first copy
+// of events that appear in more than one (matched on iCalUId), and sorts
+// the result chronologically
+func MergeEvents(lists ...[]schema.CalendarEvent) []schema.CalendarEvent {
+	merged := []schema.CalendarEvent{}
+	seen := make(map[string]bool)
+
+	for _, events := range lists {
+		for _, event := range events {
+			key := event.ICalUID
+			if key == "" {
+				key = "id:" + event.ID
+			}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged = append(merged, event)
+		}
+	}
+
+	sortEvents(merged)
+	return merged
+}
+
+// sortEvents sorts events chronologically with ID as a tie-breaker
+func sortEvents(events []schema.CalendarEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		// Primary sort: by start time
+		if !events[i].Start.Equal(events[j].Start) {
+			return events[i].Start.Before(events[j].Start)
+		}
+		// Secondary sort: by ID for deterministic ordering of overlapping events
+		return events[i].ID < events[j].ID
+	})
+}
@@ -0,0 +1,60 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+// TestMergeEvents verifies de-duplication on iCalUId and chronological ordering
+func TestMergeEvents(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2026, 7, 1, hour, 0, 0, 0, time.UTC) }
+	main := &schema.CalendarRef{ID: "CAL-1", Name: "Calendar"}
+	project := &schema.CalendarRef{ID: "CAL-2", Name: "Project X"}
+
+	first := []schema.CalendarEvent{
+		{ID: "A1", ICalUID: "shared", Start: at(10), Calendar: main},
+		{ID: "A2", ICalUID: "only-main", Start: at(14), Calendar: main},
+	}
+	second := []schema.CalendarEvent{
+		{ID: "B1", ICalUID: "shared", Start: at(10), Calendar: project},
+		{ID: "B2", ICalUID: "only-project", Start: at(9), Calendar: project},
+		{ID: "B3", Start: at(16), Calendar: project},
+	}
+
+	merged := MergeEvents(first, second)
+
+	wantIDs := []string{"B2", "A1", "A2", "B3"}
+	if len(merged) != len(wantIDs) {
+		t.Fatalf("Expected %d events, got %d", len(wantIDs), len(merged))
+	}
+	for i, id := range wantIDs {
+		if merged[i].ID != id {
+			t.Errorf("Event %d: expected %s, got %s", i, id, merged[i].ID)
+		}
+	}
+
+	// The duplicate keeps the copy from the first calendar listed
+	if merged[1].Calendar != main {
+		t.Errorf("Expected shared event from the first calendar, got %+v", merged[1].Calendar)
+	}
+}
+
+// TestFindCalendar verifies lookup by ID and case-insensitive name
+func TestFindCalendar(t *testing.T) {
+	calendars := []schema.Calendar{
+		{ID: "CAL-1", Name: "Calendar"},
+		{ID: "CAL-2", Name: "Project X"},
+	}
+
+	if cal, ok := FindCalendar(calendars, "CAL-2"); !ok || cal.Name != "Project X" {
+		t.Errorf("Expected lookup by ID, got %+v, %v", cal, ok)
+	}
+	if cal, ok := FindCalendar(calendars, "project x"); !ok || cal.ID != "CAL-2" {
+		t.Errorf("Expected lookup by name, got %+v, %v", cal, ok)
+	}
+	if _, ok := FindCalendar(calendars, "Holidays"); ok {
+		t.Error("Expected unknown calendar to not be found")
+	}
+}
@@ -3,13 +3,16 @@ package calendar
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
-	"sort"
+	"strings"
 	"time"
 
+	"github.com/obsidian-outlook-sync/outlook-md/internal/htmlmd"
+	"github.com/obsidian-outlook-sync/outlook-md/internal/timezone"
 	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
 )
 
@@ -17,7 +20,28 @@ import (
 // This interface enables mocking for testing (per research.md AD-001)
 type GraphClient interface {
 	// GetCalendarView fetches calendar events within the specified time window
+	// The timezone is an IANA name; it is sent to Graph as the matching Windows name
 	GetCalendarView(ctx context.Context, start, end time.Time, timezone string) ([]schema.CalendarEvent, error)
+
+	// GetCalendarViewFor fetches events from a specific calendar, tagging
+	// each event with the calendar it was read from
+	GetCalendarViewFor(ctx context.Context, src Source, start, end time.Time, timezone string) ([]schema.CalendarEvent, error)
+
+	// GetCalendarViewDelta fetches the changes to a calendar window since
+	// state (nil for an initial sync) using a delta query
+	GetCalendarViewDelta(ctx context.Context, src Source, start, end time.Time, timezone string, state *DeltaState) (*DeltaResult, error)
+
+	// GetSeriesInstances fetches the occurrences within a window of the
+	// recurring series an event belongs to; eventID may be the series master
+	// or any of its occurrences. The filter applies as for the calendar view.
+	GetSeriesInstances(ctx context.Context, src Source, eventID string, start, end time.Time, timezone string) ([]schema.CalendarEvent, error)
+
+	// ListCalendars lists the signed-in user's calendars
+	ListCalendars(ctx context.Context) ([]schema.Calendar, error)
+
+	// RevokeSignInSessions invalidates the refresh tokens issued to the
+	// signed-in user by every app, signing them out everywhere
+	RevokeSignInSessions(ctx context.Context) error
 }
 
 // Ensure interface is implemented at compile time
@@ -25,36 +49,68 @@ var _ GraphClient = (*graphClientImpl)(nil)
 
 // graphClientImpl implements the GraphClient interface
 type graphClientImpl struct {
-	accessToken string
-	baseURL     string
-	httpClient  *http.Client
+	tokens     TokenSource
+	baseURL    string
+	httpClient *http.Client
+	filter     Filter
+	retry      RetryPolicy
+	warn       func(err error)
+}
+
+// Option configures a Graph client
+type Option func(*graphClientImpl)
+
+// WithFilter sets the filter applied to calendar view results
+// (default: DefaultFilter)
+func WithFilter(f Filter) Option {
+	return func(c *graphClientImpl) {
+		c.filter = f
+	}
 }
 
-// NewGraphClient creates a new Microsoft Graph client
-func NewGraphClient(accessToken string) GraphClient {
-	return &graphClientImpl{
-		accessToken: accessToken,
-		baseURL:     "https://graph.microsoft.com/v1.0",
-		httpClient:  &http.Client{Timeout: 30 * time.Second},
+// WithWarnings sets a function told about failures that only leave details
+// out of the results, such as the recurrence of a series whose master could
+// not be fetched (default: they are ignored)
+func WithWarnings(warn func(err error)) Option {
+	return func(c *graphClientImpl) {
+		c.warn = warn
 	}
 }
 
+// NewGraphClient creates a new Microsoft Graph client authenticating with
+// tokens from the given source
+func NewGraphClient(tokens TokenSource, opts ...Option) GraphClient {
+	return NewGraphClientWithBaseURL(tokens, "https://graph.microsoft.com/v1.0", opts...)
+}
+
 // NewGraphClientWithBaseURL creates a new Microsoft Graph client with a custom base URL
-// This is primarily used for testing with mock servers
-func NewGraphClientWithBaseURL(accessToken, baseURL string) GraphClient {
-	return &graphClientImpl{
-		accessToken: accessToken,
-		baseURL:     baseURL,
-		httpClient:  &http.Client{Timeout: 30 * time.Second},
+// This is used for national clouds and for testing with mock servers
+func NewGraphClientWithBaseURL(tokens TokenSource, baseURL string, opts ...Option) GraphClient {
+	c := &graphClientImpl{
+		tokens:     tokens,
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		filter:     DefaultFilter(),
+		retry:      DefaultRetryPolicy(),
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // GetCalendarView implements the GraphClient interface
-func (c *graphClientImpl) GetCalendarView(ctx context.Context, start, end time.Time, timezone string) ([]schema.CalendarEvent, error) {
+func (c *graphClientImpl) GetCalendarView(ctx context.Context, start, end time.Time, tz string) ([]schema.CalendarEvent, error) {
+	return c.GetCalendarViewFor(ctx, Source{}, start, end, tz)
+}
+
+// GetCalendarViewFor implements the GraphClient interface
+func (c *graphClientImpl) GetCalendarViewFor(ctx context.Context, src Source, start, end time.Time, tz string) ([]schema.CalendarEvent, error) {
+	ctx = c.withRetryDeadline(ctx)
 	var allEvents []graphEvent
 
 	// Build URL with query parameters
-	endpoint := fmt.Sprintf("%s/me/calendarView", c.baseURL)
+	endpoint := c.baseURL + src.calendarViewPath()
 	u, err := url.Parse(endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse endpoint URL: %w", err)
@@ -64,46 +120,20 @@ func (c *graphClientImpl) GetCalendarView(ctx context.Context, start, end time.T
 	q := u.Query()
 	q.Set("startDateTime", start.Format(time.RFC3339))
 	q.Set("endDateTime", end.Format(time.RFC3339))
-	// Include all events regardless of response status
-	q.Set("$top", "999")  // Increase page size to reduce pagination
+	// Request just the properties graphEvent decodes, keeping pages small
+	q.Set("$select", selectEventFields())
+	q.Set("$top", "999") // Increase page size to reduce pagination
 	u.RawQuery = q.Encode()
 
 	nextURL := u.String()
+	header := preferTimezoneHeader(tz)
 
 	// Handle pagination - fetch all pages
 	for nextURL != "" {
-		// Create HTTP request
-		req, err := http.NewRequestWithContext(ctx, "GET", nextURL, nil)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create request: %w", err)
-		}
-
-		// Set headers
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.accessToken))
-		req.Header.Set("Prefer", fmt.Sprintf("outlook.timezone=\"%s\"", timezone))
-		req.Header.Set("Content-Type", "application/json")
-
-		// Execute request
-		resp, err := c.httpClient.Do(req)
-		if err != nil {
-			return nil, fmt.Errorf("HTTP request failed: %w", err)
-		}
-
-		// Check status code
-		if resp.StatusCode != http.StatusOK {
-			// Read error response body
-			bodyBytes, _ := io.ReadAll(resp.Body)
-			resp.Body.Close()
-			return nil, fmt.Errorf("Graph API returned status %d: %s", resp.StatusCode, string(bodyBytes))
-		}
-
-		// Parse response
 		var graphResp graphCalendarResponse
-		if err := json.NewDecoder(resp.Body).Decode(&graphResp); err != nil {
-			resp.Body.Close()
-			return nil, fmt.Errorf("failed to decode response: %w", err)
+		if err := c.get(ctx, nextURL, header, &graphResp); err != nil {
+			return nil, err
 		}
-		resp.Body.Close()
 
 		// Append events from this page
 		allEvents = append(allEvents, graphResp.Value...)
@@ -113,12 +143,212 @@ func (c *graphClientImpl) GetCalendarView(ctx context.Context, start, end time.T
 	}
 
 	// Convert Graph API events to schema.CalendarEvent
-	events, err := parseCalendarEvents(allEvents, timezone)
+	events, err := parseCalendarEvents(allEvents, tz)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse events: %w", err)
 	}
 
-	return events, nil
+	// Tag events read from an explicitly selected calendar
+	if ref := src.ref(); ref != nil {
+		for i := range events {
+			events[i].Calendar = ref
+		}
+	}
+
+	// calendarView reports the recurrence on series masters only
+	c.addSeriesRecurrence(ctx, src, events, nil)
+
+	return filterEvents(events, c.filter), nil
+}
+
+// RevokeSignInSessions implements the GraphClient interface
+func (c *graphClientImpl) RevokeSignInSessions(ctx context.Context) error {
+	var result struct {
+		Value bool `json:"value"`
+	}
+	if err := c.do(ctx, http.MethodPost, c.baseURL+"/me/revokeSignInSessions", nil, &result); err != nil {
+		return err
+	}
+	if !result.Value {
+		return fmt.Errorf("Graph did not revoke the sign-in sessions")
+	}
+	return nil
+}
+
+// preferTimezoneHeader asks Graph to report event times in tz; Graph
+// expects Windows timezone names in the Prefer header
+func preferTimezoneHeader(tz string) http.Header {
+	preferTimezone, ok := timezone.ToWindows(tz)
+	if !ok {
+		preferTimezone = tz
+	}
+	header := http.Header{}
+	header.Set("Prefer", fmt.Sprintf("outlook.timezone=\"%s\"", preferTimezone))
+	return header
+}
+
+// get fetches a Graph URL and decodes the JSON response into v (see do)
+func (c *graphClientImpl) get(ctx context.Context, rawURL string, header http.Header, v interface{}) error {
+	return c.do(ctx, http.MethodGet, rawURL, header, v)
+}
+
+// do sends a request to a Graph URL and decodes the JSON response into v,
+// retrying transient failures according to the client's retry policy. If
+// Graph rejects the access token, the token is refreshed and the request
+// replayed once.
+func (c *graphClientImpl) do(ctx context.Context, method, rawURL string, header http.Header, v interface{}) error {
+	ctx = c.withRetryDeadline(ctx)
+	deadline, _ := ctx.Value(retryDeadlineKey{}).(time.Time)
+	refreshed := false
+	for attempt := 1; ; attempt++ {
+		err := c.doOnce(ctx, method, rawURL, header, v)
+
+		var authErr *GraphError
+		if errors.As(err, &authErr) && authErr.StatusCode == http.StatusUnauthorized && !refreshed {
+			refreshed = true
+			if _, refreshErr := c.tokens.Refresh(); refreshErr != nil {
+				return fmt.Errorf("%w (re-authentication failed: %v)", err, refreshErr)
+			}
+			attempt-- // The replay is not a retry
+			continue
+		}
+
+		if err == nil || attempt >= c.retry.MaxAttempts || !c.shouldRetry(ctx, err) {
+			return err
+		}
+
+		// Graph tells throttled clients how long to wait; otherwise back off
+		wait := c.retry.backoff(attempt)
+		var graphErr *GraphError
+		if errors.As(err, &graphErr) && graphErr.RetryAfter > 0 {
+			wait = graphErr.RetryAfter
+		}
+		if !deadline.IsZero() && time.Now().Add(wait).After(deadline) {
+			return err
+		}
+
+		if c.retry.OnRetry != nil {
+			c.retry.OnRetry(err, wait)
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// shouldRetry reports whether a failed request may succeed if retried
+func (c *graphClientImpl) shouldRetry(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var graphErr *GraphError
+	if errors.As(err, &graphErr) {
+		return retryableStatus(graphErr.StatusCode)
+	}
+	var netErr *transportError
+	return errors.As(err, &netErr)
+}
+
+// doOnce performs a single request and decodes the JSON response into v
+func (c *graphClientImpl) doOnce(ctx context.Context, method, rawURL string, header http.Header, v interface{}) error {
+	// Create HTTP request
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set headers
+	for k, values := range header {
+		req.Header[k] = values
+	}
+	accessToken, err := c.tokens.Token()
+	if err != nil {
+		return fmt.Errorf("failed to get access token: %w", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Execute request
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return &transportError{err: err}
+	}
+	defer resp.Body.Close()
+
+	// Check status code
+	if resp.StatusCode != http.StatusOK {
+		// Read error response body
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		graphErr := newGraphError(resp.StatusCode, bodyBytes)
+		graphErr.RetryAfter, _ = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		return graphErr
+	}
+
+	// Parse response
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// transportError is returned when a request fails before Graph responds
+// (e.g. network unreachable, connection reset, timeout)
+type transportError struct {
+	err error
+}
+
+func (e *transportError) Error() string {
+	return fmt.Sprintf("HTTP request failed: %v", e.err)
+}
+
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+// GraphError is returned when Graph responds with a non-200 status
+type GraphError struct {
+	StatusCode int
+	Code       string        // Graph error code, e.g. "ErrorAccessDenied"
+	Message    string        // Graph error message
+	RequestID  string        // Request ID to quote when reporting issues to Microsoft
+	Body       string        // Raw response body
+	RetryAfter time.Duration // Wait requested by a Retry-After header, if any
+}
+
+// graphErrorEnvelope is the body of a Graph error response
+type graphErrorEnvelope struct {
+	Error struct {
+		Code       string `json:"code"`
+		Message    string `json:"message"`
+		InnerError struct {
+			RequestID string `json:"request-id"`
+		} `json:"innerError"`
+	} `json:"error"`
+}
+
+// newGraphError builds a GraphError, parsing the error envelope when present
+func newGraphError(status int, body []byte) *GraphError {
+	e := &GraphError{StatusCode: status, Body: string(body)}
+
+	var envelope graphErrorEnvelope
+	if err := json.Unmarshal(body, &envelope); err == nil {
+		e.Code = envelope.Error.Code
+		e.Message = envelope.Error.Message
+		e.RequestID = envelope.Error.InnerError.RequestID
+	}
+	return e
+}
+
+func (e *GraphError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("Graph API returned status %d: %s", e.StatusCode, strings.TrimSpace(e.Body))
+	}
+	msg := fmt.Sprintf("Graph API returned status %d (%s)", e.StatusCode, e.Code)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
 }
 
 // graphCalendarResponse represents the Microsoft Graph API response
@@ -130,6 +360,7 @@ type graphCalendarResponse struct {
 // graphEvent represents a calendar event from Microsoft Graph API
 type graphEvent struct {
 	ID       string `json:"id"`
+	ICalUID  string `json:"iCalUId"`
 	Subject  string `json:"subject"`
 	IsAllDay bool   `json:"isAllDay"`
 	Start    struct {
@@ -154,43 +385,85 @@ type graphEvent struct {
 			Name    string `json:"name"`
 			Address string `json:"address"`
 		} `json:"emailAddress"`
-		Type string `json:"type"` // "required", "optional", or "resource"
+		Type   string `json:"type"` // "required", "optional", or "resource"
+		Status struct {
+			Response string `json:"response"` // As responseStatus
+			Time     string `json:"time"`     // 0001-01-01T00:00:00Z if none
+		} `json:"status"`
 	} `json:"attendees"`
+	Body struct {
+		ContentType string `json:"contentType"` // "html" or "text"
+		Content     string `json:"content"`
+	} `json:"body"`
+	BodyPreview    string `json:"bodyPreview"`
 	ResponseStatus struct {
 		Response string `json:"response"` // "none", "organizer", "tentativelyAccepted", "accepted", "declined", "notResponded"
 	} `json:"responseStatus"`
+	IsOnlineMeeting       bool   `json:"isOnlineMeeting"`
+	OnlineMeetingProvider string `json:"onlineMeetingProvider"` // "teamsForBusiness", "skypeForBusiness", "skypeForConsumer" or "unknown"
+	OnlineMeeting         *struct {
+		JoinURL         string   `json:"joinUrl"`
+		ConferenceID    string   `json:"conferenceId"`
+		TollNumber      string   `json:"tollNumber"`
+		TollFreeNumbers []string `json:"tollFreeNumbers"`
+		QuickDial       string   `json:"quickDial"`
+		Phones          []struct {
+			Number string `json:"number"`
+			Type   string `json:"type"`
+		} `json:"phones"`
+	} `json:"onlineMeeting"` // null unless isOnlineMeeting
+	Type                       string           `json:"type"` // "singleInstance", "occurrence", "exception" or "seriesMaster"
+	SeriesMasterID             string           `json:"seriesMasterId"`
+	OriginalStart              string           `json:"originalStart"` // RFC 3339, for occurrences and exceptions
+	Recurrence                 *graphRecurrence `json:"recurrence"`    // null except on series masters
+	Categories                 []string         `json:"categories"`
+	Importance                 string           `json:"importance"`
+	Sensitivity                string           `json:"sensitivity"`
+	ShowAs                     string           `json:"showAs"`
+	IsCancelled                bool             `json:"isCancelled"`
+	HasAttachments             bool             `json:"hasAttachments"`
+	IsReminderOn               bool             `json:"isReminderOn"`
+	ReminderMinutesBeforeStart int              `json:"reminderMinutesBeforeStart"`
+	CreatedDateTime            string           `json:"createdDateTime"`      // RFC 3339 in UTC
+	LastModifiedDateTime       string           `json:"lastModifiedDateTime"` // RFC 3339 in UTC
+	WebLink                    string           `json:"webLink"`
+}
+
+// eventFields lists the event properties requested with $select; every
+// graphEvent field must be listed here, or Graph will not return it
+var eventFields = []string{
+	"id", "iCalUId", "subject", "isAllDay", "start", "end", "location",
+	"organizer", "attendees", "body", "bodyPreview", "responseStatus",
+	"isOnlineMeeting", "onlineMeetingProvider", "onlineMeeting",
+	"type", "seriesMasterId", "originalStart", "recurrence",
+	"categories", "importance", "sensitivity", "showAs", "isCancelled",
+	"hasAttachments", "isReminderOn", "reminderMinutesBeforeStart",
+	"createdDateTime", "lastModifiedDateTime", "webLink",
+}
+
+// selectEventFields returns the $select value requesting eventFields
+func selectEventFields() string {
+	return strings.Join(eventFields, ",")
 }
 
 // parseCalendarEvents converts Graph API events to our schema
-func parseCalendarEvents(graphEvents []graphEvent, timezone string) ([]schema.CalendarEvent, error) {
+func parseCalendarEvents(graphEvents []graphEvent, tz string) ([]schema.CalendarEvent, error) {
 	events := make([]schema.CalendarEvent, 0, len(graphEvents))
 
 	// Load timezone for parsing
-	loc, err := time.LoadLocation(timezone)
+	loc, err := timezone.LoadLocation(tz)
 	if err != nil {
 		return nil, fmt.Errorf("invalid timezone: %w", err)
 	}
 
 	for _, ge := range graphEvents {
-		// Filter: Only include accepted events or events where user is the organizer
-		response := ge.ResponseStatus.Response
-		if response != "accepted" && response != "organizer" {
-			continue
-		}
-
-		// Filter: Skip events where user is organizer but there are no invitees
-		// (solo meetings with no attendees)
-		if response == "organizer" && len(ge.Attendees) == 0 {
-			continue
-		}
-
 		// Parse start/end times
-		start, err := parseDateTime(ge.Start.DateTime, loc)
+		start, err := parseDateTime(ge.Start.DateTime, eventLocation(ge.Start.TimeZone, loc), loc)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse start time for event %s: %w", ge.ID, err)
 		}
 
-		end, err := parseDateTime(ge.End.DateTime, loc)
+		end, err := parseDateTime(ge.End.DateTime, eventLocation(ge.End.TimeZone, loc), loc)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse end time for event %s: %w", ge.ID, err)
 		}
@@ -199,9 +472,14 @@ func parseCalendarEvents(graphEvents []graphEvent, timezone string) ([]schema.Ca
 		attendees := make([]schema.Attendee, len(ge.Attendees))
 		for i, a := range ge.Attendees {
 			attendees[i] = schema.Attendee{
-				Name:  a.EmailAddress.Name,
-				Email: a.EmailAddress.Address,
-				Type:  a.Type,
+				Name:     a.EmailAddress.Name,
+				Email:    a.EmailAddress.Address,
+				Type:     a.Type,
+				Response: a.Status.Response,
+			}
+			if t, err := time.Parse(time.RFC3339, a.Status.Time); err == nil && t.Year() > 1 {
+				t = t.In(loc)
+				attendees[i].ResponseTime = &t
 			}
 		}
 
@@ -211,6 +489,7 @@ func parseCalendarEvents(graphEvents []graphEvent, timezone string) ([]schema.Ca
 		// Build event
 		event := schema.CalendarEvent{
 			ID:       ge.ID,
+			ICalUID:  ge.ICalUID,
 			Subject:  ge.Subject,
 			IsAllDay: ge.IsAllDay,
 			Start:    start,
@@ -220,27 +499,125 @@ func parseCalendarEvents(graphEvents []graphEvent, timezone string) ([]schema.Ca
 				Name:  ge.Organizer.EmailAddress.Name,
 				Email: ge.Organizer.EmailAddress.Address,
 			},
-			Attendees: attendees,
+			Attendees:      attendees,
+			Body:           convertBody(ge.Body.ContentType, ge.Body.Content),
+			BodyPreview:    ge.BodyPreview,
+			ResponseStatus: ge.ResponseStatus.Response,
+
+			IsOnlineMeeting: ge.IsOnlineMeeting,
+		}
+		event.AttendeeResponses = countResponses(attendees)
+		if ge.OnlineMeetingProvider != "unknown" {
+			event.OnlineMeetingProvider = ge.OnlineMeetingProvider
+		}
+		if om := ge.OnlineMeeting; om != nil {
+			event.OnlineMeeting = &schema.OnlineMeeting{
+				JoinURL:         om.JoinURL,
+				ConferenceID:    om.ConferenceID,
+				TollNumber:      om.TollNumber,
+				TollFreeNumbers: om.TollFreeNumbers,
+				QuickDial:       om.QuickDial,
+			}
+			for _, p := range om.Phones {
+				event.OnlineMeeting.Phones = append(event.OnlineMeeting.Phones, schema.Phone{Number: p.Number, Type: p.Type})
+			}
+			event.JoinURL = om.JoinURL
+		}
+		if event.JoinURL == "" {
+			// Meetings created by other services carry their link in the
+			// invite; search the raw body, as convertBody drops join blocks
+			event.JoinURL = FindJoinURL(ge.Location.DisplayName, ge.Body.Content)
+		}
+
+		event.Categories = ge.Categories
+		event.Importance = ge.Importance
+		event.Sensitivity = ge.Sensitivity
+		event.ShowAs = ge.ShowAs
+		event.IsCancelled = ge.IsCancelled
+		event.HasAttachments = ge.HasAttachments
+		if ge.IsReminderOn {
+			minutes := ge.ReminderMinutesBeforeStart
+			event.ReminderMinutesBeforeStart = &minutes
+		}
+		if event.CreatedDateTime, err = parseOptionalTime(ge.CreatedDateTime, loc); err != nil {
+			return nil, fmt.Errorf("failed to parse creation time for event %s: %w", ge.ID, err)
+		}
+		if event.LastModifiedDateTime, err = parseOptionalTime(ge.LastModifiedDateTime, loc); err != nil {
+			return nil, fmt.Errorf("failed to parse modification time for event %s: %w", ge.ID, err)
+		}
+		event.WebLink = ge.WebLink
+
+		event.Type = ge.Type
+		event.SeriesMasterID = ge.SeriesMasterID
+		if event.OriginalStart, err = parseOptionalTime(ge.OriginalStart, loc); err != nil {
+			return nil, fmt.Errorf("failed to parse original start for event %s: %w", ge.ID, err)
+		}
+		if ge.Recurrence != nil {
+			event.Recurrence = ge.Recurrence.toSchema()
 		}
 
 		events = append(events, event)
 	}
 
 	// Sort events chronologically with stable sort for same-time events
-	sort.SliceStable(events, func(i, j int) bool {
-		// Primary sort: by start time
-		if !events[i].Start.Equal(events[j].Start) {
-			return events[i].Start.Before(events[j].Start)
-		}
-		// Secondary sort: by ID for deterministic ordering of overlapping events
-		return events[i].ID < events[j].ID
-	})
+	sortEvents(events)
 
 	return events, nil
 }
 
-// parseDateTime parses a datetime string in the given timezone
-func parseDateTime(dtStr string, loc *time.Location) (time.Time, error) {
+// filterEvents returns the events matching f, preserving order, without
+// the attendees f hides
+func filterEvents(events []schema.CalendarEvent, f Filter) []schema.CalendarEvent {
+	filtered := make([]schema.CalendarEvent, 0, len(events))
+	for _, event := range events {
+		if f.Match(event) {
+			if f.HideDeclined {
+				event.Attendees = withoutDeclined(event.Attendees)
+			}
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
+
+// convertBody converts an event body to markdown
+func convertBody(contentType, content string) string {
+	if strings.EqualFold(contentType, "html") {
+		return htmlmd.Convert(content)
+	}
+	return htmlmd.Clean(content)
+}
+
+// eventLocation resolves the timezone Graph reported for an event time
+// (usually a Windows name such as "GMT Standard Time"), falling back to
+// the requested location when it is missing or unknown
+func eventLocation(name string, fallback *time.Location) *time.Location {
+	if name == "" {
+		return fallback
+	}
+	loc, err := timezone.LoadLocation(name)
+	if err != nil {
+		return fallback
+	}
+	return loc
+}
+
+// parseOptionalTime parses an RFC 3339 timestamp that Graph may leave out,
+// converting it to loc; nil if s is empty
+func parseOptionalTime(s string, loc *time.Location) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := parseDateTime(s, loc, loc)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// parseDateTime parses a datetime string reported in the src timezone
+// and converts it to the loc timezone
+func parseDateTime(dtStr string, src, loc *time.Location) (time.Time, error) {
 	// Try parsing as RFC3339 first
 	t, err := time.Parse(time.RFC3339, dtStr)
 	if err == nil {
@@ -248,10 +625,10 @@ func parseDateTime(dtStr string, loc *time.Location) (time.Time, error) {
 	}
 
 	// Try parsing without timezone info (Graph API sometimes returns this format)
-	t, err = time.ParseInLocation("2006-01-02T15:04:05", dtStr, loc)
+	t, err = time.ParseInLocation("2006-01-02T15:04:05", dtStr, src)
 	if err != nil {
 		return time.Time{}, fmt.Errorf("failed to parse datetime '%s': %w", dtStr, err)
 	}
 
-	return t, nil
+	return t.In(loc), nil
 }
@@ -0,0 +1,27 @@
+package calendar
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestEventFieldsSelectGraphEvent verifies that $select requests every
+// property graphEvent decodes, so that none is silently left empty
+func TestEventFieldsSelectGraphEvent(t *testing.T) {
+	selected := map[string]bool{}
+	for _, field := range eventFields {
+		selected[field] = true
+	}
+
+	typ := reflect.TypeOf(graphEvent{})
+	for i := 0; i < typ.NumField(); i++ {
+		name := strings.Split(typ.Field(i).Tag.Get("json"), ",")[0]
+		if !selected[name] {
+			t.Errorf("graphEvent field %s (%q) is missing from eventFields", typ.Field(i).Name, name)
+		}
+	}
+	if len(eventFields) != typ.NumField() {
+		t.Errorf("eventFields lists %d properties, graphEvent has %d fields", len(eventFields), typ.NumField())
+	}
+}
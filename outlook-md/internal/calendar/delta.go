@@ -0,0 +1,272 @@
+package calendar
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+// deltaPageSize is the page size requested for delta queries, which do not support $top
+const deltaPageSize = 100
+
+// DeltaState is the persisted state of a calendarView delta query:
+// the deltaLink to resume from and the raw Graph events seen so far
+type DeltaState struct {
+	DeltaLink string                     `json:"deltaLink"`
+	Events    map[string]json.RawMessage `json:"events"`
+
+	// Recurrences of the series the events belong to, by series master ID
+	Recurrences map[string]*schema.Recurrence `json:"recurrences,omitempty"`
+}
+
+// DeltaResult holds the current events of a window and what changed since
+// the previous sync
+type DeltaResult struct {
+	Events  []schema.CalendarEvent // All current events; changed ones have Change set
+	Removed []schema.RemovedEvent  // Events removed from the window since the previous sync
+	State   *DeltaState            // State to persist for the next sync
+}
+
+// graphDeltaResponse represents a page of a delta query
+type graphDeltaResponse struct {
+	Value     []json.RawMessage `json:"value"`
+	NextLink  string            `json:"@odata.nextLink"`
+	DeltaLink string            `json:"@odata.deltaLink"`
+}
+
+// graphDeltaItem holds the fields needed to classify a delta entry
+type graphDeltaItem struct {
+	ID      string          `json:"id"`
+	Removed json.RawMessage `json:"@removed"`
+}
+
+// GetCalendarViewDelta implements the GraphClient interface
+func (c *graphClientImpl) GetCalendarViewDelta(ctx context.Context, src Source, start, end time.Time, tz string, state *DeltaState) (*DeltaResult, error) {
+	ctx = c.withRetryDeadline(ctx)
+	header := preferTimezoneHeader(tz)
+	header.Add("Prefer", fmt.Sprintf("odata.maxpagesize=%d", deltaPageSize))
+
+	previous := map[string]json.RawMessage{}
+	nextURL := ""
+	var recurrences map[string]*schema.Recurrence
+	if state != nil && state.DeltaLink != "" {
+		previous = state.Events
+		nextURL = state.DeltaLink
+		recurrences = state.Recurrences
+	}
+
+	current, changed, removed, deltaLink, err := c.fetchDelta(ctx, nextURL, src, start, end, header, previous)
+	var graphErr *GraphError
+	if errors.As(err, &graphErr) && graphErr.StatusCode == http.StatusGone {
+		// The sync state expired; start over with a full sync
+		previous, recurrences = map[string]json.RawMessage{}, nil
+		current, changed, removed, deltaLink, err = c.fetchDelta(ctx, "", src, start, end, header, previous)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse every current event, then mark the ones that changed
+	graphEvents := make([]graphEvent, 0, len(current))
+	for id, raw := range current {
+		var ge graphEvent
+		if err := json.Unmarshal(raw, &ge); err != nil {
+			return nil, fmt.Errorf("failed to decode event %s: %w", id, err)
+		}
+		graphEvents = append(graphEvents, ge)
+	}
+	events, err := parseCalendarEvents(graphEvents, tz)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse events: %w", err)
+	}
+
+	ref := src.ref()
+	for i := range events {
+		events[i].Calendar = ref
+		events[i].Change = changed[events[i].ID]
+	}
+
+	// Fetch the recurrence again only for series with changes, so that an
+	// unchanged window costs no more than the delta query
+	known := make(map[string]*schema.Recurrence, len(recurrences))
+	for id, recurrence := range recurrences {
+		known[id] = recurrence
+	}
+	for _, event := range events {
+		if event.Change != "" {
+			delete(known, event.SeriesMasterID)
+		}
+	}
+	recurrences = c.addSeriesRecurrence(ctx, src, events, known)
+
+	removedEvents := make([]schema.RemovedEvent, 0, len(removed))
+	for _, id := range removed {
+		var ge graphEvent
+		_ = json.Unmarshal(previous[id], &ge)
+		removedEvents = append(removedEvents, schema.RemovedEvent{ID: id, Subject: ge.Subject, Calendar: ref})
+	}
+
+	return &DeltaResult{
+		Events:  filterEvents(events, c.filter),
+		Removed: removedEvents,
+		State:   &DeltaState{DeltaLink: deltaLink, Events: current, Recurrences: recurrences},
+	}, nil
+}
+
+// fetchDelta follows a delta query from nextURL (or a fresh query when empty)
+// to its deltaLink, applying the changes to a copy of previous. It returns
+// the current raw events, the change kind of each changed event and the IDs
+// of removed events that were previously known.
+func (c *graphClientImpl) fetchDelta(ctx context.Context, nextURL string, src Source, start, end time.Time, header http.Header, previous map[string]json.RawMessage) (map[string]json.RawMessage, map[string]string, []string, string, error) {
+	if nextURL == "" {
+		u, err := url.Parse(c.baseURL + src.calendarViewPath() + "/delta")
+		if err != nil {
+			return nil, nil, nil, "", fmt.Errorf("failed to parse endpoint URL: %w", err)
+		}
+		// Delta queries do not support $select; Graph returns the default
+		// event properties, which include every field of graphEvent
+		q := u.Query()
+		q.Set("startDateTime", start.Format(time.RFC3339))
+		q.Set("endDateTime", end.Format(time.RFC3339))
+		u.RawQuery = q.Encode()
+		nextURL = u.String()
+	}
+
+	current := make(map[string]json.RawMessage, len(previous))
+	for id, raw := range previous {
+		current[id] = raw
+	}
+	changed := map[string]string{}
+	var removed []string
+
+	for {
+		var page graphDeltaResponse
+		if err := c.get(ctx, nextURL, header, &page); err != nil {
+			return nil, nil, nil, "", err
+		}
+
+		for _, raw := range page.Value {
+			var item graphDeltaItem
+			if err := json.Unmarshal(raw, &item); err != nil {
+				return nil, nil, nil, "", fmt.Errorf("failed to decode delta entry: %w", err)
+			}
+
+			if item.Removed != nil {
+				if _, known := current[item.ID]; known {
+					delete(current, item.ID)
+					delete(changed, item.ID)
+					if _, wasPrevious := previous[item.ID]; wasPrevious {
+						removed = append(removed, item.ID)
+					}
+				}
+				continue
+			}
+
+			if _, known := previous[item.ID]; known {
+				changed[item.ID] = schema.ChangeUpdated
+			} else {
+				changed[item.ID] = schema.ChangeAdded
+			}
+			current[item.ID] = raw
+		}
+
+		if page.NextLink != "" {
+			nextURL = page.NextLink
+			continue
+		}
+		if page.DeltaLink == "" {
+			return nil, nil, nil, "", fmt.Errorf("delta response has neither nextLink nor deltaLink")
+		}
+		return current, changed, removed, page.DeltaLink, nil
+	}
+}
+
+// DeltaKey identifies the delta state of a calendar, window and timezone
+func DeltaKey(src Source, start, end time.Time, tz string) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n%s\n%s", src.calendarViewPath(), start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339), tz)
+	return hex.EncodeToString(h.Sum(nil))[:32]
+}
+
+// LoadDeltaState reads a delta state file; a missing file yields nil state
+func LoadDeltaState(path string) (*DeltaState, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read delta state: %w", err)
+	}
+
+	var state DeltaState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse delta state %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+// SaveDeltaState atomically writes a delta state file with 0600 permissions
+func SaveDeltaState(path string, state *DeltaState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal delta state: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create delta state directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".delta-*")
+	if err != nil {
+		return fmt.Errorf("failed to write delta state: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write delta state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write delta state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write delta state: %w", err)
+	}
+	return nil
+}
+
+// PruneDeltaStates removes delta state files in dir not updated within maxAge
+// (e.g. windows for past days that will not be synced again)
+func PruneDeltaStates(dir string, maxAge time.Duration) error {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	cutoff := time.Now().Add(-maxAge)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if info.ModTime().Before(cutoff) {
+			os.Remove(filepath.Join(dir, entry.Name()))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package calendar
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestDeltaKey verifies that state keys differ per calendar, window and timezone
+func TestDeltaKey(t *testing.T) {
+	start := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	key := DeltaKey(Source{}, start, end, "UTC")
+	if len(key) != 32 {
+		t.Errorf("Expected 32 character key, got %q", key)
+	}
+	if key != DeltaKey(Source{Name: "ignored"}, start, end, "UTC") {
+		t.Error("Expected display name to not affect the key")
+	}
+
+	others := []string{
+		DeltaKey(Source{CalendarID: "CAL-2"}, start, end, "UTC"),
+		DeltaKey(Source{User: "alice@corp.com"}, start, end, "UTC"),
+		DeltaKey(Source{}, start, end.Add(24*time.Hour), "UTC"),
+		DeltaKey(Source{}, start, end, "Europe/London"),
+	}
+	for i, other := range others {
+		if other == key {
+			t.Errorf("Variant %d: expected different key", i)
+		}
+	}
+}
+
+// TestDeltaStateRoundTrip verifies saving and loading state and its permissions
+func TestDeltaStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "delta", "state.json")
+
+	state, err := LoadDeltaState(path)
+	if err != nil || state != nil {
+		t.Fatalf("Expected nil state for missing file, got %+v, %v", state, err)
+	}
+
+	saved := &DeltaState{
+		DeltaLink: "https://graph.example/delta?$deltatoken=abc",
+		Events:    map[string]json.RawMessage{"EVENT-1": json.RawMessage(`{"id":"EVENT-1"}`)},
+	}
+	if err := SaveDeltaState(path, saved); err != nil {
+		t.Fatalf("SaveDeltaState failed: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected 0600 permissions, got %v", info.Mode().Perm())
+	}
+
+	loaded, err := LoadDeltaState(path)
+	if err != nil {
+		t.Fatalf("LoadDeltaState failed: %v", err)
+	}
+	if loaded.DeltaLink != saved.DeltaLink || string(loaded.Events["EVENT-1"]) != `{"id":"EVENT-1"}` {
+		t.Errorf("Unexpected state after round trip: %+v", loaded)
+	}
+
+	if err := os.WriteFile(path, []byte("not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadDeltaState(path); err == nil {
+		t.Error("Expected error for corrupt state")
+	}
+}
+
+// TestPruneDeltaStates verifies that only stale state files are removed
+func TestPruneDeltaStates(t *testing.T) {
+	dir := t.TempDir()
+	stale := filepath.Join(dir, "stale.json")
+	fresh := filepath.Join(dir, "fresh.json")
+	for _, path := range []string{stale, fresh} {
+		if err := os.WriteFile(path, []byte("{}"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(stale, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := PruneDeltaStates(dir, 24*time.Hour); err != nil {
+		t.Fatalf("PruneDeltaStates failed: %v", err)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Error("Expected stale state to be removed")
+	}
+	if _, err := os.Stat(fresh); err != nil {
+		t.Error("Expected fresh state to be kept")
+	}
+
+	if err := PruneDeltaStates(filepath.Join(dir, "missing"), time.Hour); err != nil {
+		t.Errorf("Expected missing directory to be ignored, got %v", err)
+	}
+}
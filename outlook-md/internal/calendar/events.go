@@ -35,3 +35,26 @@ func sortAttendees(attendees []schema.Attendee) {
 		return nameA < nameB
 	})
 }
+
+// countResponses tallies the responses of invitees, leaving out resources
+// and the organizer
+func countResponses(attendees []schema.Attendee) schema.ResponseCounts {
+	var counts schema.ResponseCounts
+	for _, a := range attendees {
+		if a.Type == string(schema.AttendeeTypeResource) {
+			continue
+		}
+		switch a.Response {
+		case ResponseAccepted:
+			counts.Accepted++
+		case ResponseTentativelyAccepted:
+			counts.Tentative++
+		case ResponseDeclined:
+			counts.Declined++
+		case ResponseOrganizer:
+		default:
+			counts.NoResponse++
+		}
+	}
+	return counts
+}
@@ -152,3 +152,22 @@ func TestSortAttendeesIsDeterministic(t *testing.T) {
 		}
 	}
 }
+
+// TestCountResponses verifies the tally of invitee responses
+func TestCountResponses(t *testing.T) {
+	attendees := []schema.Attendee{
+		{Email: "a@example.com", Type: "required", Response: ResponseAccepted},
+		{Email: "b@example.com", Type: "required", Response: ResponseAccepted},
+		{Email: "c@example.com", Type: "optional", Response: ResponseTentativelyAccepted},
+		{Email: "d@example.com", Type: "required", Response: ResponseDeclined},
+		{Email: "e@example.com", Type: "required", Response: ResponseNone},
+		{Email: "f@example.com", Type: "optional", Response: ResponseNotResponded},
+		{Email: "g@example.com", Type: "required", Response: ResponseOrganizer},
+		{Email: "room@example.com", Type: "resource", Response: ResponseAccepted},
+	}
+
+	want := schema.ResponseCounts{Accepted: 2, Tentative: 1, Declined: 1, NoResponse: 2}
+	if got := countResponses(attendees); got != want {
+		t.Errorf("countResponses() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,115 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+// Response status values reported by Graph for the signed-in user
+const (
+	ResponseNone                = "none"
+	ResponseOrganizer           = "organizer"
+	ResponseTentativelyAccepted = "tentativelyAccepted"
+	ResponseAccepted            = "accepted"
+	ResponseDeclined            = "declined"
+	ResponseNotResponded        = "notResponded"
+)
+
+// ResponseStatuses lists every response status in display order
+var ResponseStatuses = []string{
+	ResponseOrganizer,
+	ResponseAccepted,
+	ResponseTentativelyAccepted,
+	ResponseNotResponded,
+	ResponseDeclined,
+	ResponseNone,
+}
+
+// Filter selects which events are returned from the calendar view
+type Filter struct {
+	// ResponseStatuses lists the user's response statuses to keep
+	ResponseStatuses []string
+	// IncludeSolo keeps events the user organized with no invitees
+	// (e.g., focus blocks and reminders)
+	IncludeSolo bool
+	// HideDeclined leaves out the attendees who declined
+	HideDeclined bool
+}
+
+// DefaultFilter keeps accepted and organized meetings and drops solo events
+func DefaultFilter() Filter {
+	return Filter{ResponseStatuses: []string{ResponseAccepted, ResponseOrganizer}}
+}
+
+// Match reports whether the event passes the filter
+func (f Filter) Match(event schema.CalendarEvent) bool {
+	if !f.includes(event.ResponseStatus) {
+		return false
+	}
+
+	// Solo events: organized by the user with no invitees
+	if event.ResponseStatus == ResponseOrganizer && len(event.Attendees) == 0 && !f.IncludeSolo {
+		return false
+	}
+
+	return true
+}
+
+// withoutDeclined returns the attendees who have not declined
+func withoutDeclined(attendees []schema.Attendee) []schema.Attendee {
+	kept := make([]schema.Attendee, 0, len(attendees))
+	for _, a := range attendees {
+		if a.Response != ResponseDeclined {
+			kept = append(kept, a)
+		}
+	}
+	return kept
+}
+
+// includes reports whether status is one of the filter's response statuses
+func (f Filter) includes(status string) bool {
+	for _, s := range f.ResponseStatuses {
+		if strings.EqualFold(s, status) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseResponseStatuses parses a comma-separated list of response statuses
+// (case-insensitive); "all" selects every status
+func ParseResponseStatuses(list string) ([]string, error) {
+	var statuses []string
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if strings.EqualFold(name, "all") {
+			return append([]string(nil), ResponseStatuses...), nil
+		}
+
+		status, ok := canonicalResponseStatus(name)
+		if !ok {
+			return nil, fmt.Errorf("invalid response status %q (valid: %s, all)", name, strings.Join(ResponseStatuses, ", "))
+		}
+		statuses = append(statuses, status)
+	}
+
+	if len(statuses) == 0 {
+		return nil, fmt.Errorf("no response statuses given")
+	}
+	return statuses, nil
+}
+
+// canonicalResponseStatus returns Graph's spelling of a response status
+func canonicalResponseStatus(name string) (string, bool) {
+	for _, s := range ResponseStatuses {
+		if strings.EqualFold(s, name) {
+			return s, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,84 @@
+package calendar
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+// TestFilterMatch verifies response status and solo event filtering
+func TestFilterMatch(t *testing.T) {
+	invitee := []schema.Attendee{{Name: "Bob", Email: "bob@example.com", Type: "required"}}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		event  schema.CalendarEvent
+		want   bool
+	}{
+		{"default keeps accepted", DefaultFilter(), schema.CalendarEvent{ResponseStatus: ResponseAccepted, Attendees: invitee}, true},
+		{"default keeps organized meeting", DefaultFilter(), schema.CalendarEvent{ResponseStatus: ResponseOrganizer, Attendees: invitee}, true},
+		{"default drops tentative", DefaultFilter(), schema.CalendarEvent{ResponseStatus: ResponseTentativelyAccepted, Attendees: invitee}, false},
+		{"default drops declined", DefaultFilter(), schema.CalendarEvent{ResponseStatus: ResponseDeclined, Attendees: invitee}, false},
+		{"default drops solo", DefaultFilter(), schema.CalendarEvent{ResponseStatus: ResponseOrganizer}, false},
+		{
+			"include solo",
+			Filter{ResponseStatuses: []string{ResponseOrganizer}, IncludeSolo: true},
+			schema.CalendarEvent{ResponseStatus: ResponseOrganizer},
+			true,
+		},
+		{
+			"include tentative",
+			Filter{ResponseStatuses: []string{ResponseAccepted, ResponseTentativelyAccepted}},
+			schema.CalendarEvent{ResponseStatus: ResponseTentativelyAccepted, Attendees: invitee},
+			true,
+		},
+		{
+			"solo requires organizer status",
+			Filter{ResponseStatuses: []string{ResponseAccepted}, IncludeSolo: true},
+			schema.CalendarEvent{ResponseStatus: ResponseOrganizer},
+			false,
+		},
+		{
+			"accepted event without attendees is not solo",
+			DefaultFilter(),
+			schema.CalendarEvent{ResponseStatus: ResponseAccepted},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Match(tt.event); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestParseResponseStatuses verifies parsing, canonical spelling and "all"
+func TestParseResponseStatuses(t *testing.T) {
+	got, err := ParseResponseStatuses("accepted, TentativelyAccepted,notresponded")
+	if err != nil {
+		t.Fatalf("ParseResponseStatuses failed: %v", err)
+	}
+	want := []string{ResponseAccepted, ResponseTentativelyAccepted, ResponseNotResponded}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	got, err = ParseResponseStatuses("all")
+	if err != nil {
+		t.Fatalf("ParseResponseStatuses(all) failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, ResponseStatuses) {
+		t.Errorf("Expected all statuses, got %v", got)
+	}
+
+	for _, invalid := range []string{"", " , ", "maybe", "accepted,maybe"} {
+		if _, err := ParseResponseStatuses(invalid); err == nil {
+			t.Errorf("Expected error for %q", invalid)
+		}
+	}
+}
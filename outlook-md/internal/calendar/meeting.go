@@ -0,0 +1,96 @@
+package calendar
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+// JoinLead is how long before its start a meeting counts as the one to join
+const JoinLead = 5 * time.Minute
+
+// urlPattern matches http(s) URLs in plain text and HTML attributes
+var urlPattern = regexp.MustCompile(`https?://[^\s"'<>()\[\]{}]+`)
+
+// meetingHosts maps the hosts of online meeting services to a check of
+// the URL path, so that links to their home pages are not mistaken for
+// meeting links
+var meetingHosts = []struct {
+	domain string // Host or parent domain
+	path   *regexp.Regexp
+}{
+	{"teams.microsoft.com", regexp.MustCompile(`^/(l/meetup-join|meet)/`)},
+	{"teams.microsoft.us", regexp.MustCompile(`^/(l/meetup-join|meet)/`)}, // GCC High and DoD (gov., dod.)
+	{"teams.live.com", regexp.MustCompile(`^/meet/`)},
+	{"zoom.us", regexp.MustCompile(`^/(j|my|w|s)/`)},
+	{"zoomgov.com", regexp.MustCompile(`^/(j|my|w|s)/`)},
+	{"meet.google.com", regexp.MustCompile(`^/[a-z]{3}-[a-z]{4}-[a-z]{3}`)},
+	{"webex.com", regexp.MustCompile(`^/(meet/|join/|[^/]+/j\.php|wbxmjs/)`)},
+}
+
+// FindJoinURL returns the first Teams, Zoom, Google Meet or Webex meeting
+// link in the given texts (plain text or HTML), unwrapping Outlook Safe
+// Links; it is the fallback for events without onlineMeeting.joinUrl
+func FindJoinURL(texts ...string) string {
+	for _, text := range texts {
+		for _, match := range urlPattern.FindAllString(text, -1) {
+			u, err := url.Parse(strings.TrimRight(html.UnescapeString(match), ".,;:!?"))
+			if err != nil {
+				continue
+			}
+			if strings.HasSuffix(u.Hostname(), ".safelinks.protection.outlook.com") {
+				if u, err = url.Parse(u.Query().Get("url")); err != nil {
+					continue
+				}
+			}
+			if isMeetingURL(u) {
+				return u.String()
+			}
+		}
+	}
+	return ""
+}
+
+// isMeetingURL reports whether u links to a meeting of a known service
+func isMeetingURL(u *url.URL) bool {
+	host := strings.ToLower(u.Hostname())
+	for _, h := range meetingHosts {
+		if (host == h.domain || strings.HasSuffix(host, "."+h.domain)) && h.path.MatchString(u.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// MeetingToJoin picks the meeting with a join link to join at now: the one
+// in progress (or starting within JoinLead) that started last, else the
+// next one. It reports false if there is none.
+func MeetingToJoin(events []schema.CalendarEvent, now time.Time) (schema.CalendarEvent, bool) {
+	var current, next *schema.CalendarEvent
+	for i := range events {
+		event := &events[i]
+		if event.JoinURL == "" || event.IsAllDay || !event.End.After(now) {
+			continue
+		}
+		if !event.Start.After(now.Add(JoinLead)) {
+			if current == nil || event.Start.After(current.Start) {
+				current = event
+			}
+		} else if next == nil || event.Start.Before(next.Start) {
+			next = event
+		}
+	}
+
+	switch {
+	case current != nil:
+		return *current, true
+	case next != nil:
+		return *next, true
+	default:
+		return schema.CalendarEvent{}, false
+	}
+}
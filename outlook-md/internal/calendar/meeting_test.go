@@ -0,0 +1,96 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+// TestFindJoinURL verifies meeting link detection in locations and bodies
+func TestFindJoinURL(t *testing.T) {
+	tests := []struct {
+		name  string
+		texts []string
+		want  string
+	}{
+		{
+			"teams link in html body",
+			[]string{"Room 1", `<a href="https://teams.microsoft.com/l/meetup-join/19%3ameeting_abc%40thread.v2/0?context=%7b%22Tid%22%7d&amp;x=1">Join</a>`},
+			"https://teams.microsoft.com/l/meetup-join/19%3ameeting_abc%40thread.v2/0?context=%7b%22Tid%22%7d&x=1",
+		},
+		{
+			"zoom link in location wins over body",
+			[]string{"https://us02web.zoom.us/j/123456789?pwd=abc", "https://meet.google.com/abc-defg-hij"},
+			"https://us02web.zoom.us/j/123456789?pwd=abc",
+		},
+		{
+			"google meet with trailing punctuation",
+			[]string{"", "Join at https://meet.google.com/abc-defg-hij."},
+			"https://meet.google.com/abc-defg-hij",
+		},
+		{
+			"webex personal room",
+			[]string{"", "Webex: https://contoso.webex.com/meet/alice"},
+			"https://contoso.webex.com/meet/alice",
+		},
+		{
+			"webex meeting",
+			[]string{"", "https://contoso.webex.com/contoso/j.php?MTID=m123"},
+			"https://contoso.webex.com/contoso/j.php?MTID=m123",
+		},
+		{
+			"safe links unwrapped",
+			[]string{"", "https://nam12.safelinks.protection.outlook.com/?url=https%3A%2F%2Fzoom.us%2Fj%2F987&data=x"},
+			"https://zoom.us/j/987",
+		},
+		{
+			"home pages are not meeting links",
+			[]string{"https://zoom.us/", "Download Teams at https://teams.microsoft.com/downloads and see https://example.com/j/1"},
+			"",
+		},
+		{"no links", []string{"Conference Room A", "Agenda"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FindJoinURL(tt.texts...); got != tt.want {
+				t.Errorf("FindJoinURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestMeetingToJoin verifies the choice of the current or next meeting
+func TestMeetingToJoin(t *testing.T) {
+	now := time.Date(2026, 1, 7, 10, 0, 0, 0, time.UTC)
+	meeting := func(id string, start, end time.Duration, link bool) schema.CalendarEvent {
+		event := schema.CalendarEvent{ID: id, Start: now.Add(start), End: now.Add(end)}
+		if link {
+			event.JoinURL = "https://meet.google.com/abc-defg-hij"
+		}
+		return event
+	}
+
+	tests := []struct {
+		name   string
+		events []schema.CalendarEvent
+		want   string
+	}{
+		{"in progress", []schema.CalendarEvent{meeting("past", -2*time.Hour, -time.Hour, true), meeting("now", -30*time.Minute, 30*time.Minute, true), meeting("later", time.Hour, 2*time.Hour, true)}, "now"},
+		{"latest started of overlapping", []schema.CalendarEvent{meeting("long", -2*time.Hour, 2*time.Hour, true), meeting("short", -10*time.Minute, 20*time.Minute, true)}, "short"},
+		{"starting soon beats in progress", []schema.CalendarEvent{meeting("ending", -time.Hour, 5*time.Minute, true), meeting("soon", 3*time.Minute, time.Hour, true)}, "soon"},
+		{"next", []schema.CalendarEvent{meeting("later", 2*time.Hour, 3*time.Hour, true), meeting("sooner", time.Hour, 2*time.Hour, true)}, "sooner"},
+		{"skips events without link", []schema.CalendarEvent{meeting("room", -10*time.Minute, time.Hour, false), meeting("later", time.Hour, 2*time.Hour, true)}, "later"},
+		{"none", []schema.CalendarEvent{meeting("past", -2*time.Hour, -time.Hour, true)}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := MeetingToJoin(tt.events, now)
+			if got.ID != tt.want || ok != (tt.want != "") {
+				t.Errorf("MeetingToJoin() = %q, %v, want %q", got.ID, ok, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+// graphRecurrence represents the recurrence of a series master; Graph
+// reports it as null on occurrences and exceptions
+type graphRecurrence struct {
+	Pattern schema.RecurrencePattern `json:"pattern"`
+	Range   schema.RecurrenceRange   `json:"range"`
+}
+
+// toSchema converts the recurrence, dropping the defaults Graph fills in
+// for fields that do not apply to the pattern or range type
+func (r graphRecurrence) toSchema() *schema.Recurrence {
+	rec := &schema.Recurrence{Pattern: r.Pattern, Range: r.Range}
+	if !strings.HasPrefix(rec.Pattern.Type, "relative") {
+		rec.Pattern.Index = ""
+	}
+	if rec.Range.Type != "endDate" {
+		rec.Range.EndDate = ""
+	}
+	if rec.Range.Type != "numbered" {
+		rec.Range.NumberOfOccurrences = 0
+	}
+	rec.Summary = RecurrenceSummary(*rec)
+	return rec
+}
+
+// weekdays maps the day names used by Graph to weekdays
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// RecurrenceSummary describes a recurrence in words, e.g. "Weekly on Tue,
+// Thu", "Every 2 months on the last Friday" or "Daily until 2026-06-30"
+func RecurrenceSummary(r schema.Recurrence) string {
+	p := r.Pattern
+	interval := p.Interval
+	if interval < 1 {
+		interval = 1
+	}
+	every := func(single, unit string) string {
+		if interval == 1 {
+			return single
+		}
+		return fmt.Sprintf("Every %d %s", interval, unit)
+	}
+
+	var summary string
+	switch p.Type {
+	case "daily":
+		summary = every("Daily", "days")
+	case "weekly":
+		summary = every("Weekly", "weeks") + " on " + dayList(p.DaysOfWeek, p.FirstDayOfWeek, true)
+	case "absoluteMonthly":
+		summary = every("Monthly", "months") + fmt.Sprintf(" on day %d", p.DayOfMonth)
+	case "relativeMonthly":
+		summary = every("Monthly", "months") + fmt.Sprintf(" on the %s %s", p.Index, dayList(p.DaysOfWeek, "", false))
+	case "absoluteYearly":
+		summary = every("Yearly", "years") + fmt.Sprintf(" on %s %d", monthName(p.Month), p.DayOfMonth)
+	case "relativeYearly":
+		summary = every("Yearly", "years") + fmt.Sprintf(" on the %s %s of %s", p.Index, dayList(p.DaysOfWeek, "", false), monthName(p.Month))
+	default:
+		summary = p.Type
+	}
+
+	switch r.Range.Type {
+	case "endDate":
+		summary += " until " + r.Range.EndDate
+	case "numbered":
+		summary += fmt.Sprintf(", %d times", r.Range.NumberOfOccurrences)
+	}
+	return summary
+}
+
+// dayList lists Graph day names in week order starting at firstDay
+// (Sunday if empty), abbreviated ("Tue, Thu") or in full ("Tuesday")
+func dayList(days []string, firstDay string, short bool) string {
+	first := weekdays[strings.ToLower(firstDay)]
+	ordered := make([]time.Weekday, 0, len(days))
+	for offset := 0; offset < 7; offset++ {
+		day := (first + time.Weekday(offset)) % 7
+		for _, name := range days {
+			if weekdays[strings.ToLower(name)] == day {
+				ordered = append(ordered, day)
+				break
+			}
+		}
+	}
+
+	names := make([]string, len(ordered))
+	for i, day := range ordered {
+		names[i] = day.String()
+		if short {
+			names[i] = names[i][:3]
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// monthName returns the name of a month numbered from 1
+func monthName(month int) string {
+	if month < 1 || month > 12 {
+		return fmt.Sprintf("month %d", month)
+	}
+	return time.Month(month).String()
+}
+
+// addSeriesRecurrence sets the recurrence of the series each occurrence and
+// exception belongs to, taking it from known or else fetching every series
+// master once, and returns the recurrences by master ID. A master that cannot
+// be fetched is reported as a warning and leaves its occurrences without one.
+func (c *graphClientImpl) addSeriesRecurrence(ctx context.Context, src Source, events []schema.CalendarEvent, known map[string]*schema.Recurrence) map[string]*schema.Recurrence {
+	recurrences := map[string]*schema.Recurrence{}
+	failed := map[string]bool{}
+	for i := range events {
+		masterID := events[i].SeriesMasterID
+		if masterID == "" || events[i].Recurrence != nil || failed[masterID] {
+			continue
+		}
+		recurrence, ok := recurrences[masterID]
+		if !ok {
+			if recurrence, ok = known[masterID]; !ok {
+				var err error
+				if recurrence, err = c.getRecurrence(ctx, src, masterID); err != nil {
+					failed[masterID] = true
+					if c.warn != nil {
+						c.warn(err)
+					}
+					continue
+				}
+			}
+			recurrences[masterID] = recurrence
+		}
+		events[i].Recurrence = recurrence
+	}
+	return recurrences
+}
+
+// getRecurrence fetches the recurrence of a series master; it is nil if
+// the master is gone or hidden (e.g. in a calendar shared as free/busy)
+func (c *graphClientImpl) getRecurrence(ctx context.Context, src Source, masterID string) (*schema.Recurrence, error) {
+	var master graphEvent
+	err := c.get(ctx, c.baseURL+src.eventPath(masterID)+"?$select=recurrence", nil, &master)
+	var graphErr *GraphError
+	if errors.As(err, &graphErr) && (graphErr.StatusCode == http.StatusNotFound || graphErr.StatusCode == http.StatusForbidden) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the recurrence of series %s: %w", masterID, err)
+	}
+	if master.Recurrence == nil {
+		return nil, nil
+	}
+	return master.Recurrence.toSchema(), nil
+}
+
+// GetSeriesInstances implements the GraphClient interface
+func (c *graphClientImpl) GetSeriesInstances(ctx context.Context, src Source, eventID string, start, end time.Time, tz string) ([]schema.CalendarEvent, error) {
+	ctx = c.withRetryDeadline(ctx)
+	header := preferTimezoneHeader(tz)
+
+	// Resolve the series master from any event of the series
+	var event graphEvent
+	if err := c.get(ctx, c.baseURL+src.eventPath(eventID)+"?$select=type,seriesMasterId,recurrence", header, &event); err != nil {
+		return nil, err
+	}
+	masterID, master := eventID, event
+	switch {
+	case event.Type == schema.EventTypeSeriesMaster:
+	case event.SeriesMasterID != "":
+		masterID, master = event.SeriesMasterID, graphEvent{}
+		if err := c.get(ctx, c.baseURL+src.eventPath(masterID)+"?$select=recurrence", header, &master); err != nil {
+			return nil, fmt.Errorf("failed to fetch series master %s: %w", masterID, err)
+		}
+	default:
+		return nil, fmt.Errorf("event %s is not part of a recurring series", eventID)
+	}
+
+	u, err := url.Parse(c.baseURL + src.eventPath(masterID) + "/instances")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse endpoint URL: %w", err)
+	}
+	q := u.Query()
+	q.Set("startDateTime", start.Format(time.RFC3339))
+	q.Set("endDateTime", end.Format(time.RFC3339))
+	q.Set("$select", selectEventFields())
+	q.Set("$top", "999")
+	u.RawQuery = q.Encode()
+
+	var instances []graphEvent
+	for nextURL := u.String(); nextURL != ""; {
+		var graphResp graphCalendarResponse
+		if err := c.get(ctx, nextURL, header, &graphResp); err != nil {
+			return nil, err
+		}
+		instances = append(instances, graphResp.Value...)
+		nextURL = graphResp.NextLink
+	}
+
+	events, err := parseCalendarEvents(instances, tz)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse events: %w", err)
+	}
+	var recurrence *schema.Recurrence
+	if master.Recurrence != nil {
+		recurrence = master.Recurrence.toSchema()
+	}
+	ref := src.ref()
+	for i := range events {
+		events[i].Recurrence = recurrence
+		events[i].Calendar = ref
+	}
+	return filterEvents(events, c.filter), nil
+}
@@ -0,0 +1,54 @@
+package calendar
+
+import (
+	"testing"
+
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+// TestRecurrenceSummary verifies the summaries of each pattern and range type
+func TestRecurrenceSummary(t *testing.T) {
+	noEnd := schema.RecurrenceRange{Type: "noEnd", StartDate: "2026-01-06"}
+	tests := []struct {
+		name    string
+		pattern schema.RecurrencePattern
+		rng     schema.RecurrenceRange
+		want    string
+	}{
+		{"daily", schema.RecurrencePattern{Type: "daily", Interval: 1}, noEnd, "Daily"},
+		{"every other day", schema.RecurrencePattern{Type: "daily", Interval: 2}, noEnd, "Every 2 days"},
+		{"weekly in week order", schema.RecurrencePattern{Type: "weekly", Interval: 1, DaysOfWeek: []string{"thursday", "tuesday"}, FirstDayOfWeek: "sunday"}, noEnd, "Weekly on Tue, Thu"},
+		{"biweekly from monday", schema.RecurrencePattern{Type: "weekly", Interval: 2, DaysOfWeek: []string{"sunday", "monday"}, FirstDayOfWeek: "monday"}, noEnd, "Every 2 weeks on Mon, Sun"},
+		{"monthly on a day", schema.RecurrencePattern{Type: "absoluteMonthly", Interval: 1, DayOfMonth: 15}, noEnd, "Monthly on day 15"},
+		{"relative monthly", schema.RecurrencePattern{Type: "relativeMonthly", Interval: 3, DaysOfWeek: []string{"friday"}, Index: "last"}, noEnd, "Every 3 months on the last Friday"},
+		{"yearly on a date", schema.RecurrencePattern{Type: "absoluteYearly", Interval: 1, Month: 3, DayOfMonth: 15}, noEnd, "Yearly on March 15"},
+		{"relative yearly", schema.RecurrencePattern{Type: "relativeYearly", Interval: 1, Month: 11, DaysOfWeek: []string{"thursday"}, Index: "fourth"}, noEnd, "Yearly on the fourth Thursday of November"},
+		{"until end date", schema.RecurrencePattern{Type: "daily", Interval: 1}, schema.RecurrenceRange{Type: "endDate", EndDate: "2026-06-30"}, "Daily until 2026-06-30"},
+		{"numbered", schema.RecurrencePattern{Type: "weekly", Interval: 1, DaysOfWeek: []string{"monday"}}, schema.RecurrenceRange{Type: "numbered", NumberOfOccurrences: 10}, "Weekly on Mon, 10 times"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RecurrenceSummary(schema.Recurrence{Pattern: tt.pattern, Range: tt.rng})
+			if got != tt.want {
+				t.Errorf("RecurrenceSummary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRecurrenceToSchema verifies that Graph's defaults for fields that do
+// not apply are dropped
+func TestRecurrenceToSchema(t *testing.T) {
+	r := graphRecurrence{
+		Pattern: schema.RecurrencePattern{Type: "weekly", Interval: 1, DaysOfWeek: []string{"tuesday"}, Index: "first", FirstDayOfWeek: "sunday"},
+		Range:   schema.RecurrenceRange{Type: "noEnd", StartDate: "2026-01-06", EndDate: "0001-01-01"},
+	}
+	got := r.toSchema()
+	if got.Pattern.Index != "" || got.Range.EndDate != "" {
+		t.Errorf("Expected index and end date to be dropped, got %+v", got)
+	}
+	if got.Summary != "Weekly on Tue" {
+		t.Errorf("Unexpected summary %q", got.Summary)
+	}
+}
@@ -0,0 +1,114 @@
+package calendar
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how Graph requests that fail transiently (throttling,
+// 502/503/504 responses, network errors) are retried. Each page of a paginated
+// response is retried on its own, so pages already fetched are kept.
+type RetryPolicy struct {
+	MaxAttempts int           // Attempts per request including the first; 1 disables retries
+	Budget      time.Duration // Time after the start of a fetch past which its requests are not retried; 0 means no limit
+	BaseDelay   time.Duration // Delay before the first retry, doubled for each further retry
+	MaxDelay    time.Duration // Upper bound of the backoff delay
+
+	// OnRetry, if set, is called before waiting to retry a failed request
+	OnRetry func(err error, wait time.Duration)
+}
+
+// DefaultRetryPolicy returns the retry policy used unless WithRetryPolicy is given
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		Budget:      60 * time.Second,
+		BaseDelay:   1 * time.Second,
+		MaxDelay:    30 * time.Second,
+	}
+}
+
+// WithRetryPolicy sets the policy for retrying transient failures
+// (default: DefaultRetryPolicy)
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *graphClientImpl) {
+		c.retry = p
+	}
+}
+
+// retryDeadlineKey is the context key of the time past which the requests
+// of a fetch are not retried
+type retryDeadlineKey struct{}
+
+// withRetryDeadline starts the retry budget of a fetch, so that it is shared
+// by all of its pages and lookups; a deadline already in ctx is kept
+func (c *graphClientImpl) withRetryDeadline(ctx context.Context) context.Context {
+	if c.retry.Budget <= 0 {
+		return ctx
+	}
+	if _, ok := ctx.Value(retryDeadlineKey{}).(time.Time); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, retryDeadlineKey{}, time.Now().Add(c.retry.Budget))
+}
+
+// retryableStatus reports whether a response status is worth retrying
+func retryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff returns the delay before retry n (1 for the first retry): an
+// exponentially growing delay with jitter over its upper half
+func (p RetryPolicy) backoff(n int) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < n && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + rand.N(d-half+1)
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if d := at.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
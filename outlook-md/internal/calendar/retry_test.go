@@ -0,0 +1,56 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+)
+
+// TestBackoff verifies exponential growth, jitter bounds and the delay cap
+func TestBackoff(t *testing.T) {
+	p := RetryPolicy{BaseDelay: time.Second, MaxDelay: 8 * time.Second}
+
+	tests := []struct {
+		retry int
+		max   time.Duration
+	}{
+		{1, 1 * time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 8 * time.Second},
+		{10, 8 * time.Second},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			d := p.backoff(tt.retry)
+			if d < tt.max/2 || d > tt.max {
+				t.Errorf("backoff(%d) = %v, want between %v and %v", tt.retry, d, tt.max/2, tt.max)
+			}
+		}
+	}
+}
+
+// TestParseRetryAfter verifies the seconds and HTTP-date forms
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"5", 5 * time.Second, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{"Wed, 01 Jul 2026 12:00:30 GMT", 30 * time.Second, true},
+		{"Wed, 01 Jul 2026 11:59:00 GMT", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package calendar
+
+import "fmt"
+
+// TokenSource supplies access tokens to the Graph client
+type TokenSource interface {
+	// Token returns the current access token, obtaining one if needed
+	Token() (string, error)
+
+	// Refresh obtains a new access token after Graph rejected the current one
+	Refresh() (string, error)
+}
+
+// staticToken is a TokenSource for a fixed access token
+type staticToken string
+
+// StaticToken returns a TokenSource that always returns accessToken and
+// cannot be refreshed (e.g. OUTLOOK_MD_ACCESS_TOKEN or tests)
+func StaticToken(accessToken string) TokenSource {
+	return staticToken(accessToken)
+}
+
+func (t staticToken) Token() (string, error) {
+	return string(t), nil
+}
+
+func (t staticToken) Refresh() (string, error) {
+	return "", fmt.Errorf("a fixed access token cannot be refreshed")
+}
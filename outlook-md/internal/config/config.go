@@ -9,41 +9,147 @@ import (
 type Config struct {
 	ClientID string
 	TenantID string
+	AuthFlow string // AuthFlowDevice, AuthFlowBrowser or AuthFlowClientCredentials
+
+	// App-only credentials for AuthFlowClientCredentials: a client secret
+	// or the path of a PEM file holding a certificate and its private key
+	ClientSecret      string
+	ClientCertificate string
+
+	CloudSettings
 }
 
-// Load loads configuration from Keychain (macOS) or environment variables
-// Priority: 1) Keychain (macOS only), 2) Environment variables
+// CloudSettings select the Microsoft cloud to sign in to and read from: a
+// preset (global, usgov, dod or china) and hosts replacing the preset's own
+type CloudSettings struct {
+	Cloud         string
+	AuthorityHost string
+	GraphHost     string
+}
+
+// Interactive sign-in flows
+const (
+	AuthFlowDevice  = "device"  // Device code flow (default)
+	AuthFlowBrowser = "browser" // Authorization code flow with PKCE and a loopback redirect
+
+	// AuthFlowClientCredentials authenticates the app itself (app-only
+	// access with application permissions) instead of signing a user in
+	AuthFlowClientCredentials = "client_credentials"
+)
+
+// Load loads configuration from environment variables or Keychain (macOS),
+// environment variables taking precedence
 func Load() (*Config, error) {
-	cfg := &Config{}
+	return LoadWithProfile(Profile{})
+}
 
-	// Try to load from Keychain first (macOS only, via build tags)
-	if keychainAvailable() {
-		clientID, err := getFromKeychain("client-id")
-		if err == nil && clientID != "" {
-			cfg.ClientID = clientID
-		}
+// Keychain access (macOS only, via build tags); variables so that tests can
+// stand in for the Keychain
+var (
+	keychainEnabled = keychainAvailable
+	keychainItem    = getFromKeychain
+)
 
-		tenantID, err := getFromKeychain("tenant-id")
-		if err == nil && tenantID != "" {
-			cfg.TenantID = tenantID
-		}
+// LoadWithProfile loads configuration, taking each value from the first of:
+// 1) Environment variables, 2) the config file profile, 3) Keychain (macOS only).
+// Before profiles existed the Keychain came first; it now comes last so that
+// the profile of each account can name its own app registration and tenant.
+func LoadWithProfile(p Profile) (*Config, error) {
+	cfg := &Config{
+		ClientID: os.Getenv("OUTLOOK_MD_CLIENT_ID"),
+		TenantID: os.Getenv("OUTLOOK_MD_TENANT_ID"),
+		AuthFlow: os.Getenv("OUTLOOK_MD_AUTH_FLOW"),
+
+		ClientSecret:      os.Getenv("OUTLOOK_MD_CLIENT_SECRET"),
+		ClientCertificate: os.Getenv("OUTLOOK_MD_CLIENT_CERTIFICATE"),
+
+		CloudSettings: LoadCloudSettings(p),
 	}
 
-	// Fallback to environment variables if not found in Keychain
 	if cfg.ClientID == "" {
-		cfg.ClientID = os.Getenv("OUTLOOK_MD_CLIENT_ID")
+		cfg.ClientID = p.ClientID
 	}
 	if cfg.TenantID == "" {
-		cfg.TenantID = os.Getenv("OUTLOOK_MD_TENANT_ID")
+		cfg.TenantID = p.TenantID
+	}
+	if cfg.AuthFlow == "" {
+		cfg.AuthFlow = p.AuthFlow
+	}
+	if cfg.ClientSecret == "" {
+		cfg.ClientSecret = p.ClientSecret
+	}
+	if cfg.ClientCertificate == "" {
+		cfg.ClientCertificate = p.ClientCertificate
+	}
+
+	// Fall back to Keychain
+	if keychainEnabled() {
+		if cfg.ClientID == "" {
+			if clientID, err := keychainItem("client-id"); err == nil {
+				cfg.ClientID = clientID
+			}
+		}
+		if cfg.TenantID == "" {
+			if tenantID, err := keychainItem("tenant-id"); err == nil {
+				cfg.TenantID = tenantID
+			}
+		}
+		if cfg.AuthFlow == AuthFlowClientCredentials && cfg.ClientSecret == "" && cfg.ClientCertificate == "" {
+			if secret, err := keychainItem("client-secret"); err == nil {
+				cfg.ClientSecret = secret
+			}
+		}
 	}
 
 	// Validate that both are set
 	if cfg.ClientID == "" {
-		return nil, fmt.Errorf("client ID not found. Please set OUTLOOK_MD_CLIENT_ID environment variable or add to Keychain:\n  security add-generic-password -s com.github.obsidian-outlook-sync -a client-id -w '<YOUR_CLIENT_ID>'")
+		return nil, fmt.Errorf("client ID not found. Please run 'outlook-md config set client_id <YOUR_CLIENT_ID>', set OUTLOOK_MD_CLIENT_ID environment variable or add to Keychain:\n  security add-generic-password -s com.github.obsidian-outlook-sync -a client-id -w '<YOUR_CLIENT_ID>'")
 	}
 	if cfg.TenantID == "" {
-		return nil, fmt.Errorf("tenant ID not found. Please set OUTLOOK_MD_TENANT_ID environment variable or add to Keychain:\n  security add-generic-password -s com.github.obsidian-outlook-sync -a tenant-id -w '<YOUR_TENANT_ID>'")
+		return nil, fmt.Errorf("tenant ID not found. Please run 'outlook-md config set tenant_id <YOUR_TENANT_ID>', set OUTLOOK_MD_TENANT_ID environment variable or add to Keychain:\n  security add-generic-password -s com.github.obsidian-outlook-sync -a tenant-id -w '<YOUR_TENANT_ID>'")
+	}
+
+	switch cfg.AuthFlow {
+	case "":
+		cfg.AuthFlow = AuthFlowDevice
+	case AuthFlowDevice, AuthFlowBrowser:
+	case AuthFlowClientCredentials:
+		if cfg.ClientSecret == "" && cfg.ClientCertificate == "" {
+			return nil, fmt.Errorf("client credentials not found. Please set client_secret or client_certificate in the profile, or OUTLOOK_MD_CLIENT_SECRET or OUTLOOK_MD_CLIENT_CERTIFICATE environment variable")
+		}
+	default:
+		return nil, CheckAuthFlow(cfg.AuthFlow)
 	}
 
 	return cfg, nil
 }
+
+// CheckAuthFlow reports an error unless flow is a supported auth_flow
+func CheckAuthFlow(flow string) error {
+	switch flow {
+	case AuthFlowDevice, AuthFlowBrowser, AuthFlowClientCredentials:
+		return nil
+	}
+	return fmt.Errorf("invalid auth_flow %q (supported: %s, %s, %s)", flow, AuthFlowDevice, AuthFlowBrowser, AuthFlowClientCredentials)
+}
+
+// LoadCloudSettings loads the cloud settings of a profile, environment
+// variables taking precedence. Unlike LoadWithProfile it needs no app
+// registration, so it also applies to OUTLOOK_MD_ACCESS_TOKEN.
+func LoadCloudSettings(p Profile) CloudSettings {
+	s := CloudSettings{
+		Cloud:         os.Getenv("OUTLOOK_MD_CLOUD"),
+		AuthorityHost: os.Getenv("OUTLOOK_MD_AUTHORITY_HOST"),
+		GraphHost:     os.Getenv("OUTLOOK_MD_GRAPH_HOST"),
+	}
+	if s.Cloud == "" {
+		s.Cloud = p.Cloud
+	}
+	if s.AuthorityHost == "" {
+		s.AuthorityHost = p.AuthorityHost
+	}
+	if s.GraphHost == "" {
+		s.GraphHost = p.GraphHost
+	}
+	return s
+}
@@ -0,0 +1,588 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultProfile is the profile used when none is selected
+const DefaultProfile = "default"
+
+// defaultProfileKey is the top-level key naming the profile used by default
+const defaultProfileKey = "default_profile"
+
+// ValueKind is the type of a setting's value
+type ValueKind int
+
+const (
+	KindString ValueKind = iota
+	KindBool
+	KindList
+)
+
+// Keys lists the settings a profile may hold
+var Keys = map[string]ValueKind{
+	"client_id":          KindString,
+	"tenant_id":          KindString,
+	"auth_flow":          KindString,
+	"client_secret":      KindString,
+	"client_certificate": KindString,
+	"cloud":              KindString,
+	"authority_host":     KindString,
+	"graph_host":         KindString,
+	"timezone":           KindString,
+	"format":             KindString,
+	"week_start":         KindString,
+	"responses":          KindString,
+	"include_solo":       KindBool,
+	"hide_declined":      KindBool,
+	"opener":             KindString,
+	"calendars":          KindList,
+	"users":              KindList,
+}
+
+// secretKeys are the settings whose values List masks
+var secretKeys = map[string]bool{
+	"client_secret": true,
+}
+
+// secretMask stands in for the value of a secret setting
+const secretMask = "****"
+
+// profileNamePattern restricts profile names to TOML bare keys
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Profile holds the settings of a named profile; empty fields are unset
+type Profile struct {
+	Name              string
+	ClientID          string
+	TenantID          string
+	AuthFlow          string
+	ClientSecret      string
+	ClientCertificate string
+	Cloud             string
+	AuthorityHost     string
+	GraphHost         string
+	Timezone          string
+	Format            string
+	WeekStart         string
+	Responses         string
+	IncludeSolo       *bool
+	HideDeclined      *bool
+	Opener            string
+	Calendars         []string
+	Users             []string
+}
+
+// File is a parsed config.toml. It keeps the original lines so that Set
+// only rewrites the line it changes and comments survive.
+type File struct {
+	path     string
+	lines    []string
+	top      map[string]value
+	profiles map[string]map[string]value
+}
+
+// value is a parsed setting and the line it was read from
+type value struct {
+	kind ValueKind
+	str  string
+	list []string
+	line int
+}
+
+// Path returns the config file location: $OUTLOOK_MD_CONFIG, else
+// $XDG_CONFIG_HOME/outlook-md/config.toml, else ~/.config/outlook-md/config.toml
+func Path() (string, error) {
+	if path := os.Getenv("OUTLOOK_MD_CONFIG"); path != "" {
+		return path, nil
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "outlook-md", "config.toml"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "outlook-md", "config.toml"), nil
+}
+
+// LoadFile reads a config file; a missing file yields an empty config
+func LoadFile(path string) (*File, error) {
+	f := &File{
+		path:     path,
+		top:      map[string]value{},
+		profiles: map[string]map[string]value{},
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	f.lines = strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if err := f.parse(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// parse reads the top-level settings and [profiles.<name>] tables
+func (f *File) parse() error {
+	section := f.top
+	inProfile := false
+	for i, raw := range f.lines {
+		line := strings.TrimSpace(stripComment(raw))
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return f.errorf(i, "invalid table header %q", line)
+			}
+			name, ok := strings.CutPrefix(strings.TrimSpace(line[1:len(line)-1]), "profiles.")
+			if !ok || !profileNamePattern.MatchString(name) {
+				return f.errorf(i, "unsupported table %s (expected [profiles.<name>])", line)
+			}
+			if _, exists := f.profiles[name]; exists {
+				return f.errorf(i, "duplicate profile %q", name)
+			}
+			section = map[string]value{}
+			f.profiles[name] = section
+			inProfile = true
+			continue
+		}
+
+		key, rawValue, ok := strings.Cut(line, "=")
+		if !ok {
+			return f.errorf(i, "expected key = value")
+		}
+		key = strings.TrimSpace(key)
+		v, err := parseValue(strings.TrimSpace(rawValue))
+		if err != nil {
+			return f.errorf(i, "%s: %v", key, err)
+		}
+		v.line = i
+
+		kind, known := Keys[key]
+		if !inProfile {
+			known, kind = key == defaultProfileKey, KindString
+		}
+		if !known {
+			return f.errorf(i, "unknown setting %q", key)
+		}
+		if v.kind != kind {
+			return f.errorf(i, "%s: expected %s", key, kindName(kind))
+		}
+		section[key] = v
+	}
+	return nil
+}
+
+// errorf reports a parse error at a line of the file
+func (f *File) errorf(line int, format string, args ...interface{}) error {
+	return fmt.Errorf("%s:%d: %s", f.path, line+1, fmt.Sprintf(format, args...))
+}
+
+// Path returns the location of the file
+func (f *File) Path() string {
+	return f.path
+}
+
+// ProfileName resolves the profile to use: name if given, else the file's
+// default_profile, else DefaultProfile
+func (f *File) ProfileName(name string) string {
+	if name != "" {
+		return name
+	}
+	if v, ok := f.top[defaultProfileKey]; ok && v.str != "" {
+		return v.str
+	}
+	return DefaultProfile
+}
+
+// Profile returns the settings of the named profile (see ProfileName).
+// Selecting a profile that does not exist is an error unless it is the
+// implicit default.
+func (f *File) Profile(name string) (Profile, error) {
+	resolved := f.ProfileName(name)
+	settings, ok := f.profiles[resolved]
+	if !ok && resolved != DefaultProfile {
+		return Profile{}, fmt.Errorf("profile %q not found in %s", resolved, f.path)
+	}
+
+	p := Profile{Name: resolved}
+	p.ClientID = settings["client_id"].str
+	p.TenantID = settings["tenant_id"].str
+	p.AuthFlow = settings["auth_flow"].str
+	p.ClientSecret = settings["client_secret"].str
+	p.ClientCertificate = settings["client_certificate"].str
+	p.Cloud = settings["cloud"].str
+	p.AuthorityHost = settings["authority_host"].str
+	p.GraphHost = settings["graph_host"].str
+	p.Timezone = settings["timezone"].str
+	p.Format = settings["format"].str
+	p.WeekStart = settings["week_start"].str
+	p.Responses = settings["responses"].str
+	if v, ok := settings["include_solo"]; ok {
+		b := v.str == "true"
+		p.IncludeSolo = &b
+	}
+	if v, ok := settings["hide_declined"]; ok {
+		b := v.str == "true"
+		p.HideDeclined = &b
+	}
+	p.Opener = settings["opener"].str
+	p.Calendars = settings["calendars"].list
+	p.Users = settings["users"].list
+	return p, nil
+}
+
+// Get returns a setting of a profile formatted as in the file, or the
+// top-level default_profile when key is "default_profile"
+func (f *File) Get(profile, key string) (string, bool) {
+	section := f.profiles[f.ProfileName(profile)]
+	if key == defaultProfileKey {
+		section = f.top
+	}
+	v, ok := section[key]
+	if !ok {
+		return "", false
+	}
+	return formatValue(v), true
+}
+
+// List returns every setting as "key = value" lines, prefixed with the
+// profile name ("work.timezone = ...") for profile settings. The values of
+// secrets such as client_secret are masked; Get returns them.
+func (f *File) List() []string {
+	var lines []string
+	if v, ok := f.top[defaultProfileKey]; ok {
+		lines = append(lines, defaultProfileKey+" = "+formatValue(v))
+	}
+
+	names := make([]string, 0, len(f.profiles))
+	for name := range f.profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		keys := make([]string, 0, len(f.profiles[name]))
+		for key := range f.profiles[name] {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			v := formatValue(f.profiles[name][key])
+			if secretKeys[key] {
+				v = secretMask
+			}
+			lines = append(lines, name+"."+key+" = "+v)
+		}
+	}
+	return lines
+}
+
+// Set changes a setting of a profile (or the top-level default_profile),
+// creating the profile if needed. List values are comma-separated.
+func (f *File) Set(profile, key, raw string) error {
+	var v value
+	if key == defaultProfileKey {
+		if !profileNamePattern.MatchString(raw) {
+			return fmt.Errorf("invalid profile name %q", raw)
+		}
+		v = value{kind: KindString, str: raw}
+	} else {
+		kind, ok := Keys[key]
+		if !ok {
+			return fmt.Errorf("unknown setting %q (known: %s)", key, strings.Join(knownKeys(), ", "))
+		}
+		switch kind {
+		case KindBool:
+			b, err := strconv.ParseBool(raw)
+			if err != nil {
+				return fmt.Errorf("%s: expected true or false", key)
+			}
+			v = value{kind: KindBool, str: strconv.FormatBool(b)}
+		case KindList:
+			v = value{kind: KindList, list: splitList(raw)}
+		default:
+			v = value{kind: KindString, str: raw}
+		}
+	}
+	line := key + " = " + formatValue(v)
+
+	if key == defaultProfileKey {
+		if old, ok := f.top[key]; ok {
+			f.replaceLine(old.line, line)
+		} else if end := f.topEnd(); end < len(f.lines) {
+			// Keep a blank line between the setting and the first table
+			f.insertLine(end, "")
+			f.insertLine(end, line)
+		} else {
+			f.insertLine(end, line)
+		}
+		return f.reparse()
+	}
+
+	name := f.ProfileName(profile)
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q", name)
+	}
+	settings, ok := f.profiles[name]
+	switch {
+	case !ok:
+		if len(f.lines) > 0 && strings.TrimSpace(f.lines[len(f.lines)-1]) != "" {
+			f.lines = append(f.lines, "")
+		}
+		f.lines = append(f.lines, "[profiles."+name+"]", line)
+	case hasKey(settings, key):
+		f.replaceLine(settings[key].line, line)
+	default:
+		f.insertLine(f.profileEnd(name), line)
+	}
+	return f.reparse()
+}
+
+// Save writes the file with 0600 permissions, creating its directory
+func (f *File) Save() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	data := strings.Join(f.lines, "\n") + "\n"
+	if err := os.WriteFile(f.path, []byte(data), 0600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// reparse refreshes the parsed settings after the lines changed
+func (f *File) reparse() error {
+	f.top = map[string]value{}
+	f.profiles = map[string]map[string]value{}
+	return f.parse()
+}
+
+// replaceLine replaces the setting on line i, keeping its indentation and
+// any trailing comment
+func (f *File) replaceLine(i int, line string) {
+	old := f.lines[i]
+	indent := old[:len(old)-len(strings.TrimLeft(old, " \t"))]
+	setting := stripComment(old)
+	if comment := old[len(setting):]; comment != "" {
+		gap := setting[len(strings.TrimRight(setting, " \t")):]
+		if gap == "" {
+			gap = " "
+		}
+		line += gap + comment
+	}
+	f.lines[i] = indent + line
+}
+
+// insertLine inserts a line before index i
+func (f *File) insertLine(i int, line string) {
+	f.lines = append(f.lines[:i], append([]string{line}, f.lines[i:]...)...)
+}
+
+// topEnd returns the index after the last top-level setting
+func (f *File) topEnd() int {
+	for i, raw := range f.lines {
+		if strings.HasPrefix(strings.TrimSpace(raw), "[") {
+			return i
+		}
+	}
+	return len(f.lines)
+}
+
+// profileEnd returns the index after the last setting of a profile's table
+func (f *File) profileEnd(name string) int {
+	end := 0
+	for _, v := range f.profiles[name] {
+		if v.line+1 > end {
+			end = v.line + 1
+		}
+	}
+	if end > 0 {
+		return end
+	}
+	header := "[profiles." + name + "]"
+	for i, raw := range f.lines {
+		if strings.TrimSpace(stripComment(raw)) == header {
+			return i + 1
+		}
+	}
+	return len(f.lines)
+}
+
+// hasKey reports whether a setting is present
+func hasKey(settings map[string]value, key string) bool {
+	_, ok := settings[key]
+	return ok
+}
+
+// knownKeys returns the setting names in order
+func knownKeys() []string {
+	keys := make([]string, 0, len(Keys))
+	for key := range Keys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// kindName describes a value kind in errors
+func kindName(kind ValueKind) string {
+	switch kind {
+	case KindBool:
+		return "true or false"
+	case KindList:
+		return "an array of strings"
+	default:
+		return "a string"
+	}
+}
+
+// splitList splits a comma-separated value, dropping empty items
+func splitList(raw string) []string {
+	list := []string{}
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// stripComment removes a trailing # comment outside of strings
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// parseValue parses the TOML values used by the config file: strings,
+// booleans and single-line arrays of strings
+func parseValue(raw string) (value, error) {
+	switch {
+	case raw == "true" || raw == "false":
+		return value{kind: KindBool, str: raw}, nil
+	case strings.HasPrefix(raw, "["):
+		if !strings.HasSuffix(raw, "]") {
+			return value{}, fmt.Errorf("arrays must be on a single line")
+		}
+		list := []string{}
+		rest := strings.TrimSpace(raw[1 : len(raw)-1])
+		for rest != "" {
+			s, n, err := parseString(rest)
+			if err != nil {
+				return value{}, err
+			}
+			list = append(list, s)
+			rest = strings.TrimSpace(rest[n:])
+			if rest == "" {
+				break
+			}
+			if rest[0] != ',' {
+				return value{}, fmt.Errorf("expected , between array items")
+			}
+			rest = strings.TrimSpace(rest[1:])
+		}
+		return value{kind: KindList, list: list}, nil
+	default:
+		s, n, err := parseString(raw)
+		if err != nil {
+			return value{}, err
+		}
+		if n != len(raw) {
+			return value{}, fmt.Errorf("unexpected text after string")
+		}
+		return value{kind: KindString, str: s}, nil
+	}
+}
+
+// parseString parses a basic ("...") or literal ('...') string at the start
+// of raw and returns it with the number of bytes consumed
+func parseString(raw string) (string, int, error) {
+	if raw == "" || (raw[0] != '"' && raw[0] != '\'') {
+		return "", 0, fmt.Errorf("expected a quoted string, true, false or an array")
+	}
+	if raw[0] == '\'' {
+		end := strings.IndexByte(raw[1:], '\'')
+		if end < 0 {
+			return "", 0, fmt.Errorf("unterminated string")
+		}
+		return raw[1 : end+1], end + 2, nil
+	}
+
+	var b strings.Builder
+	for i := 1; i < len(raw); i++ {
+		c := raw[i]
+		switch c {
+		case '"':
+			return b.String(), i + 1, nil
+		case '\\':
+			i++
+			if i == len(raw) {
+				return "", 0, fmt.Errorf("unterminated string")
+			}
+			switch raw[i] {
+			case '"', '\\':
+				b.WriteByte(raw[i])
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			default:
+				return "", 0, fmt.Errorf("unsupported escape \\%c", raw[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string")
+}
+
+// formatValue formats a value as TOML
+func formatValue(v value) string {
+	switch v.kind {
+	case KindBool:
+		return v.str
+	case KindList:
+		items := make([]string, len(v.list))
+		for i, item := range v.list {
+			items[i] = quote(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	default:
+		return quote(v.str)
+	}
+}
+
+// quote formats a TOML basic string
+func quote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`)
+	return `"` + r.Replace(s) + `"`
+}
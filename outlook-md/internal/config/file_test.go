@@ -0,0 +1,327 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testConfig = `# outlook-md configuration
+default_profile = "work"
+
+[profiles.work]
+client_id = "work-client"   # Azure app registration
+tenant_id = 'work-tenant'
+timezone = "Europe/London"
+calendars = ["Calendar", "Project \"X\""]
+include_solo = true
+
+[profiles.personal]
+client_id = "personal-client"
+format = "markdown"
+`
+
+// writeConfig writes a config file and returns its path
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestLoadFileProfiles verifies profile parsing and default profile selection
+func TestLoadFileProfiles(t *testing.T) {
+	f, err := LoadFile(writeConfig(t, testConfig))
+	if err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+
+	work, err := f.Profile("")
+	if err != nil {
+		t.Fatalf("Profile failed: %v", err)
+	}
+	if work.Name != "work" || work.ClientID != "work-client" || work.TenantID != "work-tenant" || work.Timezone != "Europe/London" {
+		t.Errorf("Unexpected default profile: %+v", work)
+	}
+	if !reflect.DeepEqual(work.Calendars, []string{"Calendar", `Project "X"`}) {
+		t.Errorf("Unexpected calendars: %q", work.Calendars)
+	}
+	if work.IncludeSolo == nil || !*work.IncludeSolo {
+		t.Errorf("Expected include_solo true, got %v", work.IncludeSolo)
+	}
+
+	personal, err := f.Profile("personal")
+	if err != nil || personal.Format != "markdown" || personal.IncludeSolo != nil {
+		t.Errorf("Unexpected personal profile: %+v, %v", personal, err)
+	}
+
+	if _, err := f.Profile("missing"); err == nil {
+		t.Error("Expected error for unknown profile")
+	}
+}
+
+// TestLoadFileMissing verifies that a missing file is an empty config
+func TestLoadFileMissing(t *testing.T) {
+	f, err := LoadFile(filepath.Join(t.TempDir(), "config.toml"))
+	if err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+	p, err := f.Profile("")
+	if err != nil || p.Name != DefaultProfile || p.ClientID != "" {
+		t.Errorf("Expected empty default profile, got %+v, %v", p, err)
+	}
+}
+
+// TestLoadFileErrors verifies that invalid files report the offending line
+func TestLoadFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"unknown key", "[profiles.work]\ncolour = \"blue\"\n", ":2: unknown setting"},
+		{"wrong type", "[profiles.work]\ninclude_solo = \"yes\"\n", ":2: include_solo: expected true or false"},
+		{"other table", "[work]\n", ":1: unsupported table"},
+		{"unterminated", "[profiles.work]\ntimezone = \"UTC\n", ":2: timezone: unterminated string"},
+		{"profile key at top", "timezone = \"UTC\"\n", ":1: unknown setting"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadFile(writeConfig(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// TestFileSet verifies that Set edits lines in place and keeps comments
+func TestFileSet(t *testing.T) {
+	path := writeConfig(t, testConfig)
+	f, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.Set("work", "timezone", "America/New_York"); err != nil {
+		t.Fatalf("Set existing failed: %v", err)
+	}
+	if err := f.Set("work", "client_id", "new-client"); err != nil {
+		t.Fatalf("Set commented failed: %v", err)
+	}
+	if err := f.Set("work", "users", "alice@example.com, bob@example.com"); err != nil {
+		t.Fatalf("Set new key failed: %v", err)
+	}
+	if err := f.Set("travel", "format", "ics"); err != nil {
+		t.Fatalf("Set new profile failed: %v", err)
+	}
+	if err := f.Set("work", "include_solo", "maybe"); err == nil {
+		t.Error("Expected error for invalid bool")
+	}
+	if err := f.Set("work", "colour", "blue"); err == nil {
+		t.Error("Expected error for unknown key")
+	}
+	if err := f.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	for _, want := range []string{
+		"# outlook-md configuration\n",
+		"client_id = \"new-client\"   # Azure app registration\n",
+		"timezone = \"America/New_York\"\n",
+		"include_solo = true\nusers = [\"alice@example.com\", \"bob@example.com\"]\n",
+		"\n[profiles.travel]\nformat = \"ics\"\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Expected saved file to contain %q, got:\n%s", want, content)
+		}
+	}
+
+	reloaded, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("Reloading failed: %v", err)
+	}
+	if got, ok := reloaded.Get("work", "users"); !ok || got != `["alice@example.com", "bob@example.com"]` {
+		t.Errorf("Unexpected users after reload: %q, %v", got, ok)
+	}
+	if got, _ := reloaded.Get("", "timezone"); got != `"America/New_York"` {
+		t.Errorf("Expected default profile to be work, got timezone %q", got)
+	}
+}
+
+// TestFileList verifies the flattened listing
+func TestFileList(t *testing.T) {
+	f, err := LoadFile(writeConfig(t, testConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		`default_profile = "work"`,
+		`personal.client_id = "personal-client"`,
+		`personal.format = "markdown"`,
+		`work.calendars = ["Calendar", "Project \"X\""]`,
+		`work.client_id = "work-client"`,
+		`work.include_solo = true`,
+		`work.tenant_id = "work-tenant"`,
+		`work.timezone = "Europe/London"`,
+	}
+	if got := f.List(); !reflect.DeepEqual(got, want) {
+		t.Errorf("List() = %q, want %q", got, want)
+	}
+}
+
+// TestFileListMasksSecrets verifies that List hides client secrets, which
+// Get still returns
+func TestFileListMasksSecrets(t *testing.T) {
+	f, err := LoadFile(writeConfig(t, "[profiles.app]\nclient_id = \"app-client\"\nclient_secret = \"s3cr3t\"\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		`app.client_id = "app-client"`,
+		`app.client_secret = ****`,
+	}
+	if got := f.List(); !reflect.DeepEqual(got, want) {
+		t.Errorf("List() = %q, want %q", got, want)
+	}
+	if v, ok := f.Get("app", "client_secret"); !ok || v != `"s3cr3t"` {
+		t.Errorf("Get(client_secret) = %q, %v", v, ok)
+	}
+}
+
+// TestLoadWithProfile verifies that environment variables override the profile
+func TestLoadWithProfile(t *testing.T) {
+	t.Setenv("OUTLOOK_MD_CLIENT_ID", "env-client")
+	t.Setenv("OUTLOOK_MD_TENANT_ID", "")
+
+	cfg, err := LoadWithProfile(Profile{ClientID: "profile-client", TenantID: "profile-tenant"})
+	if err != nil {
+		t.Fatalf("LoadWithProfile failed: %v", err)
+	}
+	if cfg.ClientID != "env-client" || cfg.TenantID != "profile-tenant" {
+		t.Errorf("Unexpected config: %+v", cfg)
+	}
+}
+
+// TestLoadWithProfilePrecedence verifies that credentials come from the
+// environment, then the profile, then the Keychain
+func TestLoadWithProfilePrecedence(t *testing.T) {
+	enabled, item := keychainEnabled, keychainItem
+	t.Cleanup(func() { keychainEnabled, keychainItem = enabled, item })
+	keychainEnabled = func() bool { return true }
+	keychainItem = func(account string) (string, error) { return "keychain-" + account, nil }
+
+	tests := []struct {
+		name    string
+		env     string
+		profile string
+		want    string
+	}{
+		{"environment", "env-client", "profile-client", "env-client"},
+		{"profile", "", "profile-client", "profile-client"},
+		{"keychain", "", "", "keychain-client-id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OUTLOOK_MD_CLIENT_ID", tt.env)
+			t.Setenv("OUTLOOK_MD_TENANT_ID", "")
+
+			cfg, err := LoadWithProfile(Profile{ClientID: tt.profile})
+			if err != nil {
+				t.Fatalf("LoadWithProfile failed: %v", err)
+			}
+			if cfg.ClientID != tt.want || cfg.TenantID != "keychain-tenant-id" {
+				t.Errorf("Expected client %q and the Keychain tenant, got %+v", tt.want, cfg)
+			}
+		})
+	}
+}
+
+// TestPath verifies the config file location precedence
+func TestPath(t *testing.T) {
+	t.Setenv("OUTLOOK_MD_CONFIG", "")
+	t.Setenv("XDG_CONFIG_HOME", "/xdg")
+	if path, _ := Path(); path != "/xdg/outlook-md/config.toml" {
+		t.Errorf("Expected XDG path, got %s", path)
+	}
+
+	t.Setenv("OUTLOOK_MD_CONFIG", "/custom/config.toml")
+	if path, _ := Path(); path != "/custom/config.toml" {
+		t.Errorf("Expected OUTLOOK_MD_CONFIG path, got %s", path)
+	}
+}
+
+// TestLoadWithProfileAuthFlow verifies the sign-in flow selection
+func TestLoadWithProfileAuthFlow(t *testing.T) {
+	t.Setenv("OUTLOOK_MD_CLIENT_ID", "")
+	t.Setenv("OUTLOOK_MD_TENANT_ID", "")
+	t.Setenv("OUTLOOK_MD_AUTH_FLOW", "")
+	p := Profile{ClientID: "client", TenantID: "tenant"}
+
+	cfg, err := LoadWithProfile(p)
+	if err != nil || cfg.AuthFlow != AuthFlowDevice {
+		t.Errorf("Expected device flow by default, got %+v, %v", cfg, err)
+	}
+
+	p.AuthFlow = AuthFlowBrowser
+	if cfg, err := LoadWithProfile(p); err != nil || cfg.AuthFlow != AuthFlowBrowser {
+		t.Errorf("Expected browser flow from profile, got %+v, %v", cfg, err)
+	}
+
+	t.Setenv("OUTLOOK_MD_AUTH_FLOW", "carrier-pigeon")
+	if _, err := LoadWithProfile(p); err == nil || !strings.Contains(err.Error(), "invalid auth_flow") {
+		t.Errorf("Expected invalid auth_flow error, got %v", err)
+	}
+}
+
+// TestLoadWithProfileClientCredentials verifies that app-only auth needs a
+// secret or certificate
+func TestLoadWithProfileClientCredentials(t *testing.T) {
+	t.Setenv("OUTLOOK_MD_CLIENT_ID", "")
+	t.Setenv("OUTLOOK_MD_TENANT_ID", "")
+	t.Setenv("OUTLOOK_MD_AUTH_FLOW", "")
+	t.Setenv("OUTLOOK_MD_CLIENT_SECRET", "")
+	t.Setenv("OUTLOOK_MD_CLIENT_CERTIFICATE", "")
+	p := Profile{ClientID: "client", TenantID: "tenant", AuthFlow: AuthFlowClientCredentials}
+
+	if _, err := LoadWithProfile(p); err == nil || !strings.Contains(err.Error(), "client credentials not found") {
+		t.Errorf("Expected missing credentials error, got %v", err)
+	}
+
+	p.ClientCertificate = "/certs/app.pem"
+	t.Setenv("OUTLOOK_MD_CLIENT_SECRET", "env-secret")
+	cfg, err := LoadWithProfile(p)
+	if err != nil {
+		t.Fatalf("LoadWithProfile failed: %v", err)
+	}
+	if cfg.ClientSecret != "env-secret" || cfg.ClientCertificate != "/certs/app.pem" {
+		t.Errorf("Unexpected credentials: %+v", cfg)
+	}
+}
+
+// TestLoadCloudSettings verifies that environment variables override the
+// profile's cloud, which applies without an app registration
+func TestLoadCloudSettings(t *testing.T) {
+	t.Setenv("OUTLOOK_MD_CLOUD", "")
+	t.Setenv("OUTLOOK_MD_AUTHORITY_HOST", "")
+	t.Setenv("OUTLOOK_MD_GRAPH_HOST", "http://127.0.0.1:8080")
+
+	got := LoadCloudSettings(Profile{Cloud: "usgov", GraphHost: "https://graph.example.com"})
+	want := CloudSettings{Cloud: "usgov", GraphHost: "http://127.0.0.1:8080"}
+	if got != want {
+		t.Errorf("LoadCloudSettings() = %+v, want %+v", got, want)
+	}
+}
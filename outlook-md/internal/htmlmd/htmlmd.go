@@ -0,0 +1,517 @@
+package htmlmd
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// skippedElements have content that is never rendered
+var skippedElements = map[string]bool{
+	"head": true, "style": true, "script": true, "title": true, "xml": true,
+}
+
+// blockElements start and end on their own line
+var blockElements = map[string]bool{
+	"div": true, "table": true, "tr": true, "blockquote": true,
+	"pre": true, "hr": true, "section": true, "article": true, "header": true,
+	"footer": true, "center": true, "dl": true, "dt": true, "dd": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+}
+
+// separatorPattern matches the underscore rules Outlook draws around the
+// Teams meeting invitation
+var separatorPattern = regexp.MustCompile(`^\s*_{20,}\s*$`)
+
+// teamsMarkers identify lines belonging to the Teams join boilerplate
+var teamsMarkers = []string{
+	"Microsoft Teams meeting",
+	"Microsoft Teams Need help?",
+	"Join Microsoft Teams Meeting",
+	"Join on your computer",
+}
+
+// teamsJoinPattern matches Teams join links, also when URL-encoded inside
+// an Outlook Safe Link
+var teamsJoinPattern = regexp.MustCompile(`(?i)teams\.(microsoft\.com|microsoft\.us|live\.com)(/|%2F)(l(/|%2F)meetup-join|meet)(/|%2F)`)
+
+// whitespacePattern matches runs of HTML whitespace within text
+var whitespacePattern = regexp.MustCompile(`[ \t\r\n\f]+`)
+
+// Convert converts an Outlook HTML body to markdown. Paragraphs and line
+// breaks become lines, lists become "- " / "1. " items indented two spaces
+// per level, links become [text](url), bold becomes **text** and the Teams
+// join boilerplate is removed.
+func Convert(s string) string {
+	c := &converter{}
+	c.run(s)
+	return Clean(c.out.String())
+}
+
+// Clean normalizes a plain-text body: line endings, trailing whitespace,
+// repeated blank lines and the Teams join boilerplate
+func Clean(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+	s = strings.ReplaceAll(s, "\u00a0", " ")
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	lines = stripTeams(lines)
+
+	// Collapse blank line runs and trim leading/trailing blank lines
+	var result []string
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			if len(result) == 0 || result[len(result)-1] == "" {
+				continue
+			}
+			line = ""
+		}
+		result = append(result, line)
+	}
+	for len(result) > 0 && result[len(result)-1] == "" {
+		result = result[:len(result)-1]
+	}
+
+	return strings.Join(result, "\n")
+}
+
+// stripTeams removes Teams join blocks: the lines between two separators
+// that hold both a marker and a join link, with the separators, and a
+// trailing block from a marker line to the end of the body that holds a
+// join link but has no closing separator
+func stripTeams(lines []string) []string {
+	last := -1
+	for i, line := range lines {
+		if separatorPattern.MatchString(line) {
+			last = i
+		}
+	}
+	for i := last + 1; i < len(lines); i++ {
+		if !isTeamsLine(lines[i]) {
+			continue
+		}
+		if hasJoinLink(lines[i:]) {
+			// Take the opening separator along if only blank lines precede the marker
+			start := i
+			if last >= 0 && strings.TrimSpace(strings.Join(lines[last+1:i], "")) == "" {
+				start = last
+			}
+			lines = lines[:start]
+		}
+		break
+	}
+
+	var separators []int
+	for i, line := range lines {
+		if separatorPattern.MatchString(line) {
+			separators = append(separators, i)
+		}
+	}
+	// Work backwards so the indexes of earlier separators stay valid
+	for k := len(separators) - 1; k > 0; k-- {
+		start, end := separators[k-1], separators[k]
+		if !hasTeamsLine(lines[start+1:end]) || !hasJoinLink(lines[start+1:end]) {
+			continue
+		}
+		lines = append(lines[:start:start], lines[end+1:]...)
+		k-- // The opening separator went with the block
+	}
+	return lines
+}
+
+// isTeamsLine reports whether line contains a Teams boilerplate marker
+func isTeamsLine(line string) bool {
+	for _, m := range teamsMarkers {
+		if strings.Contains(line, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasTeamsLine reports whether any of lines contains a Teams boilerplate marker
+func hasTeamsLine(lines []string) bool {
+	for _, line := range lines {
+		if isTeamsLine(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasJoinLink reports whether any of lines contains a Teams join link
+func hasJoinLink(lines []string) bool {
+	for _, line := range lines {
+		if teamsJoinPattern.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// list tracks an open <ul> or <ol>
+type list struct {
+	ordered bool
+	n       int
+}
+
+// link tracks an open <a> and where its text starts in the output
+type link struct {
+	href  string
+	start int
+}
+
+// converter walks HTML tokens and writes markdown
+type converter struct {
+	out     strings.Builder
+	skip    int      // depth inside skipped elements
+	lists   []list   // open lists, innermost last
+	links   []link   // open links, innermost last
+	pending []string // emphasis markers waiting for text
+	pre     int      // depth inside <pre>
+	para    int      // output length when the current <p> opened
+}
+
+// run tokenizes s into comments, tags and text
+func (c *converter) run(s string) {
+	for len(s) > 0 {
+		lt := strings.IndexByte(s, '<')
+		if lt < 0 {
+			c.text(s)
+			return
+		}
+		if lt > 0 {
+			c.text(s[:lt])
+			s = s[lt:]
+		}
+
+		switch {
+		case strings.HasPrefix(s, "<!--"):
+			// Comments, including Outlook's conditional comments
+			end := strings.Index(s, "-->")
+			if end < 0 {
+				return
+			}
+			s = s[end+3:]
+		case strings.HasPrefix(s, "<!"), strings.HasPrefix(s, "<?"):
+			end := strings.IndexByte(s, '>')
+			if end < 0 {
+				return
+			}
+			s = s[end+1:]
+		default:
+			name, attrs, closing, n := parseTag(s)
+			if n == 0 {
+				// A lone "<" is text
+				c.text("<")
+				s = s[1:]
+				continue
+			}
+			s = s[n:]
+			if closing {
+				c.end(name)
+			} else {
+				c.start(name, attrs)
+			}
+		}
+	}
+}
+
+// parseTag parses the tag at the start of s, returning its lowercased
+// name (without namespace prefix), attributes, whether it is a closing tag
+// and its length; n is 0 if s does not start with a tag
+func parseTag(s string) (name string, attrs map[string]string, closing bool, n int) {
+	i := 1
+	if i < len(s) && s[i] == '/' {
+		closing = true
+		i++
+	}
+	nameStart := i
+	for i < len(s) && (isAlnum(s[i]) || s[i] == ':' || s[i] == '-') {
+		i++
+	}
+	if i == nameStart {
+		return "", nil, false, 0
+	}
+	name = strings.ToLower(s[nameStart:i])
+	if colon := strings.LastIndexByte(name, ':'); colon >= 0 {
+		name = name[colon+1:]
+	}
+
+	attrs = map[string]string{}
+	for i < len(s) {
+		for i < len(s) && isSpace(s[i]) {
+			i++
+		}
+		if i >= len(s) {
+			break
+		}
+		if s[i] == '>' {
+			return name, attrs, closing, i + 1
+		}
+		if s[i] == '/' {
+			i++
+			continue
+		}
+
+		keyStart := i
+		for i < len(s) && !isSpace(s[i]) && s[i] != '=' && s[i] != '>' && s[i] != '/' {
+			i++
+		}
+		key := strings.ToLower(s[keyStart:i])
+		for i < len(s) && isSpace(s[i]) {
+			i++
+		}
+		value := ""
+		if i < len(s) && s[i] == '=' {
+			i++
+			for i < len(s) && isSpace(s[i]) {
+				i++
+			}
+			if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+				quote := s[i]
+				end := strings.IndexByte(s[i+1:], quote)
+				if end < 0 {
+					value, i = s[i+1:], len(s)
+				} else {
+					value, i = s[i+1:i+1+end], i+end+2
+				}
+			} else {
+				valueStart := i
+				for i < len(s) && !isSpace(s[i]) && s[i] != '>' {
+					i++
+				}
+				value = s[valueStart:i]
+			}
+		}
+		if key != "" {
+			attrs[key] = html.UnescapeString(value)
+		}
+	}
+
+	// Unterminated tag: consume the rest of the input
+	return name, attrs, closing, len(s)
+}
+
+// start handles an opening tag
+func (c *converter) start(name string, attrs map[string]string) {
+	if skippedElements[name] {
+		c.skip++
+		return
+	}
+	if c.skip > 0 {
+		return
+	}
+
+	switch name {
+	case "br":
+		c.out.WriteString("\n")
+	case "ul", "ol":
+		c.newline()
+		c.lists = append(c.lists, list{ordered: name == "ol"})
+	case "li":
+		c.newline()
+		depth := len(c.lists)
+		if depth == 0 {
+			c.lists = append(c.lists, list{})
+			depth = 1
+		}
+		l := &c.lists[depth-1]
+		c.out.WriteString(strings.Repeat("  ", depth-1))
+		if l.ordered {
+			l.n++
+			fmt.Fprintf(&c.out, "%d. ", l.n)
+		} else {
+			c.out.WriteString("- ")
+		}
+	case "a":
+		c.links = append(c.links, link{href: strings.TrimSpace(attrs["href"]), start: c.out.Len()})
+	case "b", "strong":
+		c.pending = append(c.pending, "**")
+	case "i", "em":
+		c.pending = append(c.pending, "*")
+	case "td", "th":
+		c.space()
+	case "p":
+		c.newline()
+		c.para = c.out.Len()
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		// Headings would clash with the note's own ## and ### structure
+		c.newline()
+		c.pending = append(c.pending, "**")
+	default:
+		if blockElements[name] {
+			if name == "pre" {
+				c.pre++
+			}
+			c.newline()
+		}
+	}
+}
+
+// end handles a closing tag
+func (c *converter) end(name string) {
+	if skippedElements[name] {
+		if c.skip > 0 {
+			c.skip--
+		}
+		return
+	}
+	if c.skip > 0 {
+		return
+	}
+
+	switch name {
+	case "ul", "ol":
+		if len(c.lists) > 0 {
+			c.lists = c.lists[:len(c.lists)-1]
+		}
+		c.newline()
+	case "li":
+		c.newline()
+	case "p":
+		// Outlook writes blank lines as empty paragraphs (<p>&nbsp;</p>)
+		if c.out.Len() == c.para && c.out.Len() > 0 {
+			c.out.WriteString("\n")
+		}
+		c.newline()
+	case "a":
+		if len(c.links) > 0 {
+			l := c.links[len(c.links)-1]
+			c.links = c.links[:len(c.links)-1]
+			c.closeLink(l)
+		}
+	case "b", "strong":
+		c.closeEmphasis("**")
+	case "i", "em":
+		c.closeEmphasis("*")
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		c.closeEmphasis("**")
+		c.newline()
+	default:
+		if blockElements[name] {
+			if name == "pre" && c.pre > 0 {
+				c.pre--
+			}
+			c.newline()
+		}
+	}
+}
+
+// text writes a text node, collapsing whitespace outside <pre>
+func (c *converter) text(s string) {
+	if c.skip > 0 {
+		return
+	}
+	s = strings.ReplaceAll(html.UnescapeString(s), "\u00a0", " ")
+	if c.pre == 0 {
+		s = whitespacePattern.ReplaceAllString(s, " ")
+		if c.atLineStart() || strings.HasSuffix(c.out.String(), " ") {
+			s = strings.TrimLeft(s, " ")
+		}
+	}
+	if s == "" {
+		return
+	}
+
+	if len(c.pending) > 0 {
+		// Emphasis markers hug the text, not the surrounding whitespace
+		trimmed := strings.TrimLeft(s, " ")
+		if trimmed == "" {
+			c.out.WriteString(s)
+			return
+		}
+		c.out.WriteString(s[:len(s)-len(trimmed)])
+		c.out.WriteString(strings.Join(c.pending, ""))
+		c.pending = nil
+		s = trimmed
+	}
+	c.out.WriteString(s)
+}
+
+// closeEmphasis closes an emphasis marker, dropping it if no text followed
+func (c *converter) closeEmphasis(marker string) {
+	for i := len(c.pending) - 1; i >= 0; i-- {
+		if c.pending[i] == marker {
+			c.pending = append(c.pending[:i], c.pending[i+1:]...)
+			return
+		}
+	}
+
+	// Move trailing spaces outside the marker
+	out := c.out.String()
+	trimmed := strings.TrimRight(out, " ")
+	c.out.Reset()
+	c.out.WriteString(trimmed)
+	c.out.WriteString(marker)
+	c.out.WriteString(out[len(trimmed):])
+}
+
+// closeLink rewrites the link text written since l.start as [text](href)
+func (c *converter) closeLink(l link) {
+	out := c.out.String()
+	text := strings.TrimSpace(out[l.start:])
+	href := l.href
+
+	if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(strings.ToLower(href), "javascript:") {
+		return
+	}
+
+	var rendered string
+	switch {
+	case text == "":
+		rendered = "<" + href + ">"
+	case text == href || "mailto:"+text == href:
+		rendered = text
+	default:
+		rendered = "[" + text + "](" + href + ")"
+	}
+
+	c.out.Reset()
+	c.out.WriteString(out[:l.start])
+	if l.start > 0 && out[l.start-1] != ' ' && out[l.start-1] != '\n' && strings.HasPrefix(out[l.start:], " ") {
+		c.out.WriteString(" ")
+	}
+	c.out.WriteString(rendered)
+}
+
+// newline ends the current line unless already at the start of one
+func (c *converter) newline() {
+	if !c.atLineStart() {
+		c.out.WriteString("\n")
+	}
+}
+
+// space separates inline content (e.g. table cells) with a single space
+func (c *converter) space() {
+	if !c.atLineStart() && !strings.HasSuffix(c.out.String(), " ") {
+		c.out.WriteString(" ")
+	}
+}
+
+// atLineStart reports whether the output is empty, ends with a newline or
+// ends with a list item prefix
+func (c *converter) atLineStart() bool {
+	out := c.out.String()
+	if out == "" || strings.HasSuffix(out, "\n") {
+		return true
+	}
+	last := out[strings.LastIndexByte(out, '\n')+1:]
+	return strings.TrimSpace(last) == "" || listPrefixPattern.MatchString(last)
+}
+
+// listPrefixPattern matches a line containing only a list item prefix
+var listPrefixPattern = regexp.MustCompile(`^ *(?:- |\d+\. )$`)
+
+func isAlnum(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9'
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == '\f'
+}
@@ -0,0 +1,154 @@
+package htmlmd
+
+import "testing"
+
+// TestConvert verifies conversion of common Outlook HTML constructs
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "paragraphs and entities",
+			html: `<html><head><style>p {margin:0}</style></head><body><p class="MsoNormal">Hi&nbsp;team,</p><p>&nbsp;</p><p>Q3 &amp; Q4   review</p></body></html>`,
+			want: "Hi team,\n\nQ3 & Q4 review",
+		},
+		{
+			name: "line breaks",
+			html: `<div>One<br>Two<br/>Three</div>`,
+			want: "One\nTwo\nThree",
+		},
+		{
+			name: "bold and italic",
+			html: `<p><b><span>Agenda:</span></b> review <strong> numbers </strong>and <i>plans</i></p>`,
+			want: "**Agenda:** review **numbers** and *plans*",
+		},
+		{
+			name: "links",
+			html: `<p>See <a href="https://example.com/doc">the doc</a>, <a href="https://example.com">https://example.com</a> or <a href="mailto:bob@example.com">bob@example.com</a></p>`,
+			want: "See [the doc](https://example.com/doc), https://example.com or bob@example.com",
+		},
+		{
+			name: "nested lists",
+			html: `<ul><li>First</li><li>Second<ol><li>Alpha</li><li>Beta</li></ol></li><li><p>Third</p></li></ul><p>After</p>`,
+			want: "- First\n- Second\n  1. Alpha\n  2. Beta\n- Third\nAfter",
+		},
+		{
+			name: "headings become bold lines",
+			html: `<h2>Goals</h2><p>Ship it</p>`,
+			want: "**Goals**\nShip it",
+		},
+		{
+			name: "comments and conditional comments",
+			html: `<!--[if gte mso 9]><xml><o:OfficeDocumentSettings></o:OfficeDocumentSettings></xml><![endif]--><p>Text<o:p></o:p></p>`,
+			want: "Text",
+		},
+		{
+			name: "stray less-than",
+			html: `<p>a < b</p>`,
+			want: "a < b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Convert(tt.html); got != tt.want {
+				t.Errorf("Convert() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestConvertStripsTeamsBoilerplate verifies the Teams join block is removed
+func TestConvertStripsTeamsBoilerplate(t *testing.T) {
+	body := `<html><body>
+<p>Let's walk through the roadmap.</p>
+<div style="width:100%"><span>________________________________________________________________________________</span></div>
+<div><span style="font-size:24px">Microsoft Teams meeting</span></div>
+<div><b>Join on your computer, mobile app or room device</b></div>
+<a href="https://teams.microsoft.com/l/meetup-join/abc">Click here to join the meeting</a>
+<div>Meeting ID: 123 456 789<br>Passcode: abc123</div>
+<div><a href="https://aka.ms/JoinTeamsMeeting">Learn More</a> | <a href="https://teams.microsoft.com/meetingOptions/">Meeting options</a></div>
+<div><span>________________________________________________________________________________</span></div>
+<p>Bring questions.</p>
+</body></html>`
+
+	want := "Let's walk through the roadmap.\nBring questions."
+	if got := Convert(body); got != want {
+		t.Errorf("Convert() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+// TestClean verifies plain-text bodies are normalized
+func TestClean(t *testing.T) {
+	text := "Agenda:\r\n- Intro   \r\n\r\n\r\n- Demo\r\n\r\n" +
+		"________________________________________________________________________________\r\n" +
+		"Microsoft Teams meeting\r\nJoin on your computer or mobile app\r\n" +
+		"Click here to join the meeting<https://teams.microsoft.com/l/meetup-join/abc>\r\n" +
+		"________________________________________________________________________________\r\n"
+
+	want := "Agenda:\n- Intro\n\n- Demo"
+	if got := Clean(text); got != want {
+		t.Errorf("Clean() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+// TestCleanTeamsWithoutSeparators verifies a trailing Teams block without
+// underscore rules is dropped from the marker line onward
+func TestCleanTeamsWithoutSeparators(t *testing.T) {
+	text := "Weekly sync\n\nMicrosoft Teams Need help?\n" +
+		"Join the meeting now<https://teams.microsoft.com/l/meetup-join/abc>\nMeeting ID: 1"
+	if got := Clean(text); got != "Weekly sync" {
+		t.Errorf("Clean() = %q, want %q", got, "Weekly sync")
+	}
+}
+
+// TestConvertKeepsTeamsMentions verifies that text mentioning a marker
+// phrase without a join link is kept
+func TestConvertKeepsTeamsMentions(t *testing.T) {
+	body := `<p>Agenda:</p><ul><li>Review the Microsoft Teams meeting policy</li><li>Budget</li></ul>`
+
+	want := "Agenda:\n- Review the Microsoft Teams meeting policy\n- Budget"
+	if got := Convert(body); got != want {
+		t.Errorf("Convert() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+// TestCleanTeamsAfterUnrelatedSeparator verifies that only the Teams block
+// is removed when an unrelated underscore rule precedes it
+func TestCleanTeamsAfterUnrelatedSeparator(t *testing.T) {
+	rule := "________________________________________________________________________________\n"
+	joinBlock := "Microsoft Teams meeting\nJoin on your computer or mobile app\n" +
+		"Click here to join the meeting<https://teams.microsoft.com/l/meetup-join/abc>\n"
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "delimited block",
+			text: "Notes\n" + rule + "Budget review\n" + rule + joinBlock + rule + "Bring questions.",
+			want: "Notes\n" + rule + "Budget review\nBring questions.",
+		},
+		{
+			name: "trailing block",
+			text: "Notes\n" + rule + "Budget review\n\n" + joinBlock + "Meeting ID: 1",
+			want: "Notes\n" + rule + "Budget review",
+		},
+		{
+			name: "marker phrase without join link",
+			text: "Notes\n" + rule + "Discuss the Microsoft Teams meeting rollout\nBudget review",
+			want: "Notes\n" + rule + "Discuss the Microsoft Teams meeting rollout\nBudget review",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Clean(tt.text); got != tt.want {
+				t.Errorf("Clean() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
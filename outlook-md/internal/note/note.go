@@ -0,0 +1,245 @@
+package note
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/obsidian-outlook-sync/outlook-md/internal/output"
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+// deletedMarker is appended to the header of events removed from the calendar
+const deletedMarker = " [deleted]"
+
+// Block is an event previously rendered inside the managed region
+type Block struct {
+	ID    string
+	Lines []string // All lines of the block, from EVENT_ID marker to the next event
+	Notes []string // Lines between NOTES_START and NOTES_END (nil if no pocket)
+}
+
+// Result summarizes a sync
+type Result struct {
+	Active  int // Events currently in the calendar
+	Deleted int // Removed events retained because they have notes
+}
+
+// FindManagedRegion returns the 0-indexed lines of the first
+// AGENDA_START/AGENDA_END pair (mirrors parser.lua find_managed_region)
+func FindManagedRegion(lines []string) (start, end int, ok bool) {
+	start = -1
+	for i, line := range lines {
+		if start < 0 && strings.Contains(line, output.AgendaStart) {
+			start = i
+		} else if start >= 0 && strings.Contains(line, output.AgendaEnd) {
+			return start, i, true
+		}
+	}
+	return -1, -1, false
+}
+
+// extractEventID extracts the ID from an EVENT_ID marker line
+func extractEventID(line string) (string, bool) {
+	idx := strings.Index(line, output.EventIDPrefix)
+	if idx < 0 {
+		return "", false
+	}
+	rest := line[idx+len(output.EventIDPrefix):]
+	end := strings.Index(rest, output.EventIDSuffix)
+	if end < 0 {
+		return "", false
+	}
+	return rest[:end], true
+}
+
+// ParseBlocks parses the events between the region markers at start and end
+// (mirrors parser.lua parse_managed_region_events)
+func ParseBlocks(lines []string, start, end int) []Block {
+	var blocks []Block
+	current := -1
+
+	flush := func(to int) {
+		if current >= 0 {
+			blocks = append(blocks, newBlock(lines[current:to]))
+		}
+	}
+
+	for i := start + 1; i < end; i++ {
+		if strings.Contains(lines[i], output.EventIDPrefix) {
+			flush(i)
+			current = i
+		}
+	}
+	flush(end)
+
+	return blocks
+}
+
+// newBlock extracts the event ID and notes pocket from a block's lines
+func newBlock(lines []string) Block {
+	block := Block{Lines: append([]string(nil), lines...)}
+
+	notesStart := -1
+	for i, line := range lines {
+		if block.ID == "" {
+			if id, ok := extractEventID(line); ok {
+				block.ID = id
+			}
+		}
+		if strings.Contains(line, output.NotesStart) {
+			notesStart = i
+		} else if strings.Contains(line, output.NotesEnd) && notesStart >= 0 {
+			block.Notes = append([]string{}, lines[notesStart+1:i]...)
+			break
+		}
+	}
+
+	return block
+}
+
+// IsMeaningfulNotes reports whether notes contain user content: at least one
+// line that is not blank, not a section header and not `- <auto>` content
+// (mirrors merger.lua is_meaningful_notes, FR-025)
+func IsMeaningfulNotes(notes []string) bool {
+	for _, line := range notes {
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" && !strings.HasPrefix(trimmed, "###") && !strings.HasPrefix(trimmed, "- <auto>") {
+			return true
+		}
+	}
+	return false
+}
+
+// Merge renders events into region lines, preserving the notes of existing
+// blocks and retaining removed events that have meaningful notes with a
+// [deleted] marker (mirrors merger.lua merge_events)
+func Merge(old []Block, events []schema.CalendarEvent) ([]string, Result) {
+	var result Result
+
+	oldByID := make(map[string]Block, len(old))
+	for _, b := range old {
+		if b.ID != "" {
+			oldByID[b.ID] = b
+		}
+	}
+	newIDs := make(map[string]bool, len(events))
+
+	var lines []string
+	for _, event := range events {
+		newIDs[event.ID] = true
+		var notes []string
+		if b, ok := oldByID[event.ID]; ok {
+			notes = b.Notes
+		}
+		lines = append(lines, output.RenderEventWithNotes(event, notes)...)
+		result.Active++
+	}
+
+	for _, b := range old {
+		if newIDs[b.ID] || !IsMeaningfulNotes(b.Notes) {
+			// Updated above, or deleted without notes (FR-024)
+			continue
+		}
+		// Retain with [deleted] marker (FR-023)
+		lines = append(lines, markDeleted(b.Lines)...)
+		result.Deleted++
+	}
+
+	if len(lines) == 0 {
+		lines = output.RenderEvents(nil)
+	}
+
+	return lines, result
+}
+
+// markDeleted appends the [deleted] marker to a block's header line
+func markDeleted(lines []string) []string {
+	marked := append([]string(nil), lines...)
+	for i, line := range marked {
+		if strings.HasPrefix(line, "## ") {
+			if !strings.HasSuffix(line, deletedMarker) {
+				marked[i] = line + deletedMarker
+			}
+			break
+		}
+	}
+	return marked
+}
+
+// SyncFile merges events into the managed region of the markdown file at path
+// and rewrites it atomically
+func SyncFile(path string, events []schema.CalendarEvent) (Result, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to read note: %w", err)
+	}
+
+	content := string(data)
+	trailingNewline := strings.HasSuffix(content, "\n")
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+
+	start, end, ok := FindManagedRegion(lines)
+	if !ok {
+		return Result{}, fmt.Errorf("could not find %s and %s markers in %s", output.AgendaStart, output.AgendaEnd, path)
+	}
+
+	region, result := Merge(ParseBlocks(lines, start, end), events)
+
+	// Replace the content between the markers, keeping the marker lines
+	updated := make([]string, 0, len(lines)+len(region))
+	updated = append(updated, lines[:start+1]...)
+	updated = append(updated, region...)
+	updated = append(updated, lines[end:]...)
+
+	newContent := strings.Join(updated, "\n")
+	if trailingNewline {
+		newContent += "\n"
+	}
+
+	if err := writeFileAtomic(path, []byte(newContent)); err != nil {
+		return Result{}, err
+	}
+
+	return result, nil
+}
+
+// writeFileAtomic writes data to a temporary file in the same directory and
+// renames it over path, so readers never see a partially written note
+func writeFileAtomic(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat note: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // No-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync temporary file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file: %w", err)
+	}
+
+	// Preserve the original file permissions
+	if err := os.Chmod(tmpPath, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to set permissions: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to replace note: %w", err)
+	}
+
+	return nil
+}
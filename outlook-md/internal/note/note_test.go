@@ -0,0 +1,245 @@
+package note
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+const existingNote = `# Daily Note - 2026-01-07
+
+## Calendar
+
+<!-- AGENDA_START -->
+<!-- EVENT_ID: kept -->
+## 09:00-09:30 Team Standup
+
+### Attendees
+Alice Smith (O)
+
+### Notes
+<!-- NOTES_START -->
+- Discussed Q1 priorities
+<!-- NOTES_END -->
+
+<!-- EVENT_ID: cancelled-with-notes -->
+## 11:00-12:00 Design Review
+
+### Attendees
+Bob Jones (O)
+
+### Notes
+<!-- NOTES_START -->
+- Prepare mockups
+<!-- NOTES_END -->
+
+<!-- EVENT_ID: cancelled-empty -->
+## 13:00-13:30 Lunch & Learn
+
+### Attendees
+Carol White (O)
+
+### Notes
+<!-- NOTES_START -->
+
+<!-- NOTES_END -->
+
+<!-- AGENDA_END -->
+
+## Notes
+- Meeting went well today
+`
+
+// testEvent builds a timed event starting at the given hour
+func testEvent(id, subject string, hour int) schema.CalendarEvent {
+	start := time.Date(2026, 1, 7, hour, 0, 0, 0, time.UTC)
+	return schema.CalendarEvent{
+		ID:        id,
+		Subject:   subject,
+		Start:     start,
+		End:       start.Add(30 * time.Minute),
+		Organizer: schema.Organizer{Name: "Alice Smith", Email: "alice@example.com"},
+		Attendees: []schema.Attendee{},
+	}
+}
+
+// TestSyncFile verifies notes are preserved, deletions are marked and the
+// content outside the managed region is untouched
+func TestSyncFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "2026-01-07.md")
+	if err := os.WriteFile(path, []byte(existingNote), 0640); err != nil {
+		t.Fatalf("Failed to write note: %v", err)
+	}
+
+	events := []schema.CalendarEvent{
+		testEvent("kept", "Team Standup (moved)", 10),
+		testEvent("new", "Planning", 15),
+	}
+
+	result, err := SyncFile(path, events)
+	if err != nil {
+		t.Fatalf("SyncFile failed: %v", err)
+	}
+	if result.Active != 2 || result.Deleted != 1 {
+		t.Errorf("Expected 2 active and 1 deleted, got %+v", result)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read note: %v", err)
+	}
+	content := string(data)
+
+	expectedRegion := strings.Join([]string{
+		"<!-- AGENDA_START -->",
+		"<!-- EVENT_ID: kept -->",
+		"## 10:00-10:30 Team Standup (moved)",
+		"",
+		"### Attendees",
+		"Alice Smith (O)",
+		"",
+		"### Notes",
+		"<!-- NOTES_START -->",
+		"- Discussed Q1 priorities",
+		"<!-- NOTES_END -->",
+		"",
+		"<!-- EVENT_ID: new -->",
+		"## 15:00-15:30 Planning",
+		"",
+		"### Attendees",
+		"Alice Smith (O)",
+		"",
+		"### Notes",
+		"<!-- NOTES_START -->",
+		"",
+		"<!-- NOTES_END -->",
+		"",
+		"<!-- EVENT_ID: cancelled-with-notes -->",
+		"## 11:00-12:00 Design Review [deleted]",
+		"",
+		"### Attendees",
+		"Bob Jones (O)",
+		"",
+		"### Notes",
+		"<!-- NOTES_START -->",
+		"- Prepare mockups",
+		"<!-- NOTES_END -->",
+		"",
+		"<!-- AGENDA_END -->",
+	}, "\n")
+
+	if !strings.Contains(content, expectedRegion) {
+		t.Errorf("Unexpected managed region:\n%s", content)
+	}
+	if strings.Contains(content, "Lunch & Learn") {
+		t.Error("Deleted event without notes should be removed")
+	}
+	if !strings.HasPrefix(content, "# Daily Note - 2026-01-07\n\n## Calendar\n\n") {
+		t.Error("Content before the managed region should be preserved")
+	}
+	if !strings.HasSuffix(content, "<!-- AGENDA_END -->\n\n## Notes\n- Meeting went well today\n") {
+		t.Error("Content after the managed region should be preserved")
+	}
+
+	// Permissions preserved and no temporary files left behind
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Failed to stat note: %v", err)
+	}
+	if info.Mode().Perm() != 0640 {
+		t.Errorf("Expected permissions 0640, got %v", info.Mode().Perm())
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("Expected only the note in the directory, found %d entries", len(entries))
+	}
+}
+
+// TestSyncFileIdempotent verifies a second sync with the same events is a no-op
+func TestSyncFileIdempotent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "note.md")
+	if err := os.WriteFile(path, []byte(existingNote), 0644); err != nil {
+		t.Fatalf("Failed to write note: %v", err)
+	}
+
+	events := []schema.CalendarEvent{testEvent("kept", "Team Standup", 9)}
+	if _, err := SyncFile(path, events); err != nil {
+		t.Fatalf("First sync failed: %v", err)
+	}
+	first, _ := os.ReadFile(path)
+
+	result, err := SyncFile(path, events)
+	if err != nil {
+		t.Fatalf("Second sync failed: %v", err)
+	}
+	second, _ := os.ReadFile(path)
+
+	if string(first) != string(second) {
+		t.Errorf("Second sync changed the note:\n%s\n---\n%s", first, second)
+	}
+	if result.Deleted != 1 || !strings.Contains(string(second), "Design Review [deleted]") {
+		t.Errorf("Deleted marker should appear once, got %+v:\n%s", result, second)
+	}
+	if strings.Contains(string(second), "[deleted] [deleted]") {
+		t.Error("Deleted marker should not be duplicated")
+	}
+}
+
+// TestSyncFileMissingMarkers verifies notes without a managed region are rejected
+func TestSyncFileMissingMarkers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "note.md")
+	original := "# No agenda here\n<!-- AGENDA_START -->\n"
+	if err := os.WriteFile(path, []byte(original), 0644); err != nil {
+		t.Fatalf("Failed to write note: %v", err)
+	}
+
+	if _, err := SyncFile(path, nil); err == nil {
+		t.Fatal("Expected error when AGENDA_END is missing")
+	}
+
+	data, _ := os.ReadFile(path)
+	if string(data) != original {
+		t.Error("Note should be unchanged on error")
+	}
+}
+
+// TestSyncFileEmptyAgenda verifies an empty calendar renders the placeholder
+func TestSyncFileEmptyAgenda(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "note.md")
+	if err := os.WriteFile(path, []byte("<!-- AGENDA_START -->\n<!-- AGENDA_END -->"), 0644); err != nil {
+		t.Fatalf("Failed to write note: %v", err)
+	}
+
+	if _, err := SyncFile(path, nil); err != nil {
+		t.Fatalf("SyncFile failed: %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	expected := "<!-- AGENDA_START -->\n*No events for this time period*\n<!-- AGENDA_END -->"
+	if string(data) != expected {
+		t.Errorf("Expected %q, got %q", expected, string(data))
+	}
+}
+
+// TestIsMeaningfulNotes verifies the FR-025 rules
+func TestIsMeaningfulNotes(t *testing.T) {
+	tests := []struct {
+		notes []string
+		want  bool
+	}{
+		{nil, false},
+		{[]string{"", "   "}, false},
+		{[]string{"### Heading", "- <auto> generated"}, false},
+		{[]string{"", "- Action item"}, true},
+	}
+
+	for _, tt := range tests {
+		if got := IsMeaningfulNotes(tt.notes); got != tt.want {
+			t.Errorf("IsMeaningfulNotes(%q) = %v, want %v", tt.notes, got, tt.want)
+		}
+	}
+}
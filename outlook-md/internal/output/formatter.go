@@ -2,11 +2,40 @@ package output
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
+	"strings"
 
 	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
 )
 
+// SupportedFormats lists the accepted values for --format
+var SupportedFormats = []string{"json", "markdown", "ics"}
+
+// Format writes output to w in the named format
+func Format(format string, output *schema.CLIOutput, w io.Writer) error {
+	switch format {
+	case "json":
+		return FormatJSON(output, w)
+	case "markdown":
+		return FormatMarkdown(output, w)
+	case "ics":
+		return FormatICS(output, w)
+	default:
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+// IsSupported reports whether format is a supported output format
+func IsSupported(format string) bool {
+	for _, f := range SupportedFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
 // FormatJSON serializes CLIOutput to JSON and writes to the provided writer
 func FormatJSON(output *schema.CLIOutput, w io.Writer) error {
 	encoder := json.NewEncoder(w)
@@ -18,3 +47,41 @@ func FormatJSON(output *schema.CLIOutput, w io.Writer) error {
 
 	return nil
 }
+
+// FormatCalendars writes a calendar list to w as json or as a markdown table
+func FormatCalendars(format string, list *schema.CalendarList, w io.Writer) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(list)
+	case "markdown":
+		lines := []string{
+			"| Name | Owner | Color | Can Edit | ID |",
+			"| --- | --- | --- | --- | --- |",
+		}
+		for _, cal := range list.Calendars {
+			name := cal.Name
+			if cal.IsDefault {
+				name += " (default)"
+			}
+			canEdit := "no"
+			if cal.CanEdit {
+				canEdit = "yes"
+			}
+			cells := []string{name, displayName(cal.Owner.Name, cal.Owner.Email), cal.Color, canEdit, cal.ID}
+			for i, cell := range cells {
+				cells[i] = strings.ReplaceAll(cell, "|", "\\|")
+			}
+			lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+		}
+		for _, line := range lines {
+			if _, err := fmt.Fprintln(w, line); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported format for calendars: %s (supported: json, markdown)", format)
+	}
+}
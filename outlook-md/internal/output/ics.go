@@ -0,0 +1,332 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+// icsProductID identifies this tool in the PRODID property
+const icsProductID = "-//obsidian-outlook-sync//outlook-md//EN"
+
+// icsMaxLineOctets is the RFC 5545 line length limit before folding
+const icsMaxLineOctets = 75
+
+// FormatICS serializes CLIOutput as an iCalendar (RFC 5545) VCALENDAR
+func FormatICS(output *schema.CLIOutput, w io.Writer) error {
+	loc, err := time.LoadLocation(output.Timezone)
+	if err != nil {
+		return fmt.Errorf("invalid timezone %q: %w", output.Timezone, err)
+	}
+	utc := isUTC(output.Timezone)
+
+	var lines []string
+	lines = append(lines,
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:"+icsProductID,
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+	)
+
+	if !utc {
+		lines = append(lines, "X-WR-TIMEZONE:"+output.Timezone)
+		lines = append(lines, vtimezone(output.Timezone, loc, output)...)
+	}
+
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+	for _, event := range output.Events {
+		lines = append(lines, vevent(event, output.Timezone, loc, utc, stamp)...)
+	}
+
+	lines = append(lines, "END:VCALENDAR")
+
+	for _, line := range lines {
+		if _, err := io.WriteString(w, foldICSLine(line)+"\r\n"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// vevent renders a single event as a VEVENT component
+func vevent(event schema.CalendarEvent, tzid string, loc *time.Location, utc bool, stamp string) []string {
+	// iCalUId identifies the event across calendars and clients
+	uid := event.ICalUID
+	if uid == "" {
+		uid = event.ID
+	}
+	lines := []string{
+		"BEGIN:VEVENT",
+		"UID:" + escapeICSText(uid),
+		"DTSTAMP:" + stamp,
+	}
+
+	if event.IsAllDay {
+		// All-day events use DATE values; DTEND is the exclusive next day
+		lines = append(lines,
+			"DTSTART;VALUE=DATE:"+event.Start.In(loc).Format("20060102"),
+			"DTEND;VALUE=DATE:"+event.End.In(loc).Format("20060102"),
+		)
+	} else if utc {
+		lines = append(lines,
+			"DTSTART:"+event.Start.UTC().Format("20060102T150405Z"),
+			"DTEND:"+event.End.UTC().Format("20060102T150405Z"),
+		)
+	} else {
+		lines = append(lines,
+			"DTSTART;TZID="+tzid+":"+event.Start.In(loc).Format("20060102T150405"),
+			"DTEND;TZID="+tzid+":"+event.End.In(loc).Format("20060102T150405"),
+		)
+	}
+
+	if event.Subject != "" {
+		lines = append(lines, "SUMMARY:"+escapeICSText(event.Subject))
+	}
+	if event.Location != "" {
+		lines = append(lines, "LOCATION:"+escapeICSText(event.Location))
+	}
+	if event.Body != "" {
+		lines = append(lines, "DESCRIPTION:"+escapeICSText(event.Body))
+	}
+	if event.WebLink != "" {
+		lines = append(lines, "URL:"+event.WebLink)
+	}
+	if len(event.Categories) > 0 {
+		categories := make([]string, len(event.Categories))
+		for i, c := range event.Categories {
+			categories[i] = escapeICSText(c)
+		}
+		lines = append(lines, "CATEGORIES:"+strings.Join(categories, ","))
+	}
+	switch event.Sensitivity {
+	case "personal", "private":
+		lines = append(lines, "CLASS:PRIVATE")
+	case "confidential":
+		lines = append(lines, "CLASS:CONFIDENTIAL")
+	}
+	switch event.Importance {
+	case "high":
+		lines = append(lines, "PRIORITY:1")
+	case "low":
+		lines = append(lines, "PRIORITY:9")
+	}
+	if event.IsCancelled {
+		lines = append(lines, "STATUS:CANCELLED")
+	}
+	if event.ShowAs == "free" {
+		lines = append(lines, "TRANSP:TRANSPARENT")
+	}
+	if event.CreatedDateTime != nil {
+		lines = append(lines, "CREATED:"+event.CreatedDateTime.UTC().Format("20060102T150405Z"))
+	}
+	if event.LastModifiedDateTime != nil {
+		lines = append(lines, "LAST-MODIFIED:"+event.LastModifiedDateTime.UTC().Format("20060102T150405Z"))
+	}
+
+	if event.Organizer.Email != "" {
+		lines = append(lines, "ORGANIZER"+cnParam(event.Organizer.Name)+":mailto:"+event.Organizer.Email)
+	}
+
+	for _, a := range event.Attendees {
+		if a.Email == "" {
+			continue
+		}
+		role, cutype := "REQ-PARTICIPANT", "INDIVIDUAL"
+		switch schema.AttendeeType(a.Type) {
+		case schema.AttendeeTypeOptional:
+			role = "OPT-PARTICIPANT"
+		case schema.AttendeeTypeResource:
+			role, cutype = "NON-PARTICIPANT", "RESOURCE"
+		}
+		lines = append(lines, fmt.Sprintf("ATTENDEE%s;ROLE=%s;CUTYPE=%s%s:mailto:%s", cnParam(a.Name), role, cutype, partstatParam(a.Response), a.Email))
+	}
+
+	if event.ReminderMinutesBeforeStart != nil {
+		lines = append(lines,
+			"BEGIN:VALARM",
+			"ACTION:DISPLAY",
+			"DESCRIPTION:"+escapeICSText(event.Subject),
+			fmt.Sprintf("TRIGGER:-PT%dM", *event.ReminderMinutesBeforeStart),
+			"END:VALARM",
+		)
+	}
+
+	lines = append(lines, "END:VEVENT")
+	return lines
+}
+
+// partstatParam returns the PARTSTAT parameter for a Graph response, or ""
+// if the response is unknown
+func partstatParam(response string) string {
+	switch response {
+	case "accepted":
+		return ";PARTSTAT=ACCEPTED"
+	case "tentativelyAccepted":
+		return ";PARTSTAT=TENTATIVE"
+	case "declined":
+		return ";PARTSTAT=DECLINED"
+	case "none", "notResponded":
+		return ";PARTSTAT=NEEDS-ACTION"
+	default:
+		return ""
+	}
+}
+
+// vtimezone renders a VTIMEZONE describing loc's offset transitions over
+// the years spanned by the output (plus the preceding year so the first
+// events are covered by an observance)
+func vtimezone(tzid string, loc *time.Location, output *schema.CLIOutput) []string {
+	first, last := output.Window.Start.In(loc).Year(), output.Window.End.In(loc).Year()
+	for _, event := range output.Events {
+		if y := event.Start.In(loc).Year(); y < first {
+			first = y
+		}
+		if y := event.End.In(loc).Year(); y > last {
+			last = y
+		}
+	}
+
+	from := time.Date(first-1, time.January, 1, 0, 0, 0, 0, loc)
+	to := time.Date(last+1, time.January, 1, 0, 0, 0, 0, loc)
+
+	lines := []string{"BEGIN:VTIMEZONE", "TZID:" + tzid}
+
+	transitions := zoneTransitions(from, to)
+	if len(transitions) == 0 {
+		// Fixed offset zone: a single STANDARD observance
+		name, offset := from.Zone()
+		lines = append(lines,
+			"BEGIN:STANDARD",
+			"DTSTART:19700101T000000",
+			"TZOFFSETFROM:"+formatICSOffset(offset),
+			"TZOFFSETTO:"+formatICSOffset(offset),
+			"TZNAME:"+name,
+			"END:STANDARD",
+		)
+	}
+
+	for _, t := range transitions {
+		component := "STANDARD"
+		if t.IsDST() {
+			component = "DAYLIGHT"
+		}
+		_, before := t.Add(-time.Second).Zone()
+		name, after := t.Zone()
+		// DTSTART is the local time of the transition in the previous offset
+		onset := t.UTC().Add(time.Duration(before) * time.Second)
+		lines = append(lines,
+			"BEGIN:"+component,
+			"DTSTART:"+onset.Format("20060102T150405"),
+			"TZOFFSETFROM:"+formatICSOffset(before),
+			"TZOFFSETTO:"+formatICSOffset(after),
+			"TZNAME:"+name,
+			"END:"+component,
+		)
+	}
+
+	lines = append(lines, "END:VTIMEZONE")
+	return lines
+}
+
+// zoneTransitions finds the instants in [from, to) where the UTC offset or
+// zone abbreviation changes, to the second
+func zoneTransitions(from, to time.Time) []time.Time {
+	var transitions []time.Time
+
+	prev := from
+	prevName, prevOffset := prev.Zone()
+	for t := from.Add(24 * time.Hour); !t.After(to); t = t.Add(24 * time.Hour) {
+		name, offset := t.Zone()
+		if name == prevName && offset == prevOffset {
+			prev = t
+			continue
+		}
+
+		// Binary search the transition within the day
+		lo, hi := prev, t
+		for hi.Sub(lo) > time.Second {
+			mid := lo.Add(hi.Sub(lo) / 2)
+			if n, o := mid.Zone(); n == prevName && o == prevOffset {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		transitions = append(transitions, hi)
+
+		prev, prevName, prevOffset = t, name, offset
+	}
+
+	return transitions
+}
+
+// isUTC reports whether times should be written in UTC form ("...Z")
+// rather than referencing a VTIMEZONE
+func isUTC(name string) bool {
+	switch name {
+	case "UTC", "Etc/UTC", "Etc/GMT", "GMT":
+		return true
+	}
+	return false
+}
+
+// formatICSOffset formats a UTC offset in seconds as +HHMM / -HHMM
+func formatICSOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, seconds/3600, (seconds%3600)/60)
+}
+
+// cnParam returns a ;CN= parameter for a display name, quoted when needed
+func cnParam(name string) string {
+	if name == "" {
+		return ""
+	}
+	name = strings.ReplaceAll(name, `"`, "'")
+	if strings.ContainsAny(name, ";:,") {
+		return `;CN="` + name + `"`
+	}
+	return ";CN=" + name
+}
+
+// escapeICSText escapes a TEXT value per RFC 5545 section 3.3.11
+func escapeICSText(s string) string {
+	r := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+	return r.Replace(s)
+}
+
+// foldICSLine folds content lines longer than 75 octets, without splitting
+// UTF-8 sequences, using CRLF followed by a single space
+func foldICSLine(line string) string {
+	if len(line) <= icsMaxLineOctets {
+		return line
+	}
+
+	var b strings.Builder
+	count := 0
+	for _, r := range line {
+		size := utf8.RuneLen(r)
+		if count+size > icsMaxLineOctets {
+			b.WriteString("\r\n ")
+			count = 1 // the leading space counts toward the next line
+		}
+		b.WriteRune(r)
+		count += size
+	}
+	return b.String()
+}
@@ -0,0 +1,165 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+// Managed region markers (must match parser.lua)
+const (
+	AgendaStart   = "<!-- AGENDA_START -->"
+	AgendaEnd     = "<!-- AGENDA_END -->"
+	EventIDPrefix = "<!-- EVENT_ID: "
+	EventIDSuffix = " -->"
+	NotesStart    = "<!-- NOTES_START -->"
+	NotesEnd      = "<!-- NOTES_END -->"
+)
+
+// maxDisplayedAttendees is the number of invitees listed before truncating
+const maxDisplayedAttendees = 5
+
+// locationPattern matches attendee names that are really rooms/locations
+// (names starting with 3+ capital letters like "NYC", "LON")
+var locationPattern = regexp.MustCompile(`^[A-Z]{3}`)
+
+// bulletPattern splits a body line into its indentation, an optional
+// unordered list bullet and the remaining text
+var bulletPattern = regexp.MustCompile(`^( *)(?:[-*+] +)?(.*)$`)
+
+// FormatMarkdown renders CLIOutput as the managed region produced by the
+// Neovim plugin (renderer.lua), wrapped in AGENDA_START/AGENDA_END markers
+func FormatMarkdown(output *schema.CLIOutput, w io.Writer) error {
+	lines := []string{AgendaStart}
+	lines = append(lines, RenderEvents(output.Events)...)
+	lines = append(lines, AgendaEnd)
+
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RenderEvents converts events to markdown lines (mirrors renderer.lua render_events)
+func RenderEvents(events []schema.CalendarEvent) []string {
+	if len(events) == 0 {
+		return []string{"*No events for this time period*"}
+	}
+
+	var lines []string
+	for _, event := range events {
+		lines = append(lines, RenderEvent(event)...)
+	}
+	return lines
+}
+
+// RenderEvent converts a single event to markdown lines (mirrors renderer.lua render_event)
+func RenderEvent(event schema.CalendarEvent) []string {
+	return RenderEventWithNotes(event, nil)
+}
+
+// RenderEventWithNotes renders an event with existing notes preserved verbatim
+// in its notes pocket (FR-020); nil notes produce an empty scaffold (FR-022)
+func RenderEventWithNotes(event schema.CalendarEvent, notes []string) []string {
+	var lines []string
+
+	// EVENT_ID marker used to match events across refreshes
+	if event.ID != "" {
+		lines = append(lines, EventIDPrefix+event.ID+EventIDSuffix)
+	}
+
+	// Event header
+	subject := event.Subject
+	if subject == "" {
+		subject = "(Untitled Event)"
+	}
+	if event.Account != "" {
+		// Label events merged from several accounts
+		subject = "[" + event.Account + "] " + subject
+	}
+	if event.IsAllDay {
+		lines = append(lines, fmt.Sprintf("## All Day - %s", subject))
+	} else {
+		lines = append(lines, fmt.Sprintf("## %s-%s %s", event.Start.Format("15:04"), event.End.Format("15:04"), subject))
+	}
+
+	// Agenda section only if body content exists
+	if event.Body != "" {
+		lines = append(lines, "", "### Agenda")
+		lines = append(lines, agendaLines(event.Body)...)
+	}
+
+	// Attendees section (organizer + invitees in a single line)
+	lines = append(lines, "", "### Attendees")
+	if names := attendeeNames(event); len(names) > 0 {
+		lines = append(lines, strings.Join(names, ", "))
+	}
+
+	// Notes pocket
+	lines = append(lines, "", "### Notes", NotesStart)
+	if notes != nil {
+		lines = append(lines, notes...)
+	} else {
+		lines = append(lines, "")
+	}
+	lines = append(lines, NotesEnd, "")
+
+	return lines
+}
+
+// agendaLines renders each non-blank body line as an "- <auto>" item,
+// reusing the body's own bullet and indentation for list items
+func agendaLines(body string) []string {
+	var lines []string
+	for _, line := range strings.Split(body, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		m := bulletPattern.FindStringSubmatch(line)
+		lines = append(lines, m[1]+"- <auto> "+m[2])
+	}
+	return lines
+}
+
+// attendeeNames lists the organizer (marked "(O)") followed by up to
+// maxDisplayedAttendees invitees, skipping locations and the organizer
+func attendeeNames(event schema.CalendarEvent) []string {
+	var names []string
+
+	orgDisplay := displayName(event.Organizer.Name, event.Organizer.Email)
+	if orgDisplay != "" {
+		names = append(names, orgDisplay+" (O)")
+	}
+
+	total := 0
+	for _, a := range event.Attendees {
+		name := displayName(a.Name, a.Email)
+		if locationPattern.MatchString(name) || a.Email == event.Organizer.Email {
+			continue
+		}
+		total++
+		if total <= maxDisplayedAttendees {
+			names = append(names, name)
+		}
+	}
+
+	if total > maxDisplayedAttendees {
+		names = append(names, fmt.Sprintf("…and %d more", total-maxDisplayedAttendees))
+	}
+
+	return names
+}
+
+// displayName returns the name, falling back to the email address
+func displayName(name, email string) string {
+	if name != "" {
+		return name
+	}
+	return email
+}
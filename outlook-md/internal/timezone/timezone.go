@@ -0,0 +1,112 @@
+package timezone
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Local returns the IANA name of the system timezone
+// Priority: 1) TZ environment variable, 2) /etc/localtime symlink, 3) /etc/timezone, 4) UTC
+func Local() string {
+	return localFrom(os.Getenv("TZ"), "/etc/localtime", "/etc/timezone")
+}
+
+// localFrom resolves the system timezone from the given sources (split out for testing)
+func localFrom(tzEnv, localtimePath, timezoneFile string) string {
+	// TZ may be a zone name, ":"-prefixed name, or a path to a zoneinfo file
+	if tzEnv != "" {
+		tzEnv = strings.TrimPrefix(tzEnv, ":")
+		if filepath.IsAbs(tzEnv) {
+			if name := zoneFromPath(tzEnv); name != "" {
+				return name
+			}
+		} else if _, err := time.LoadLocation(tzEnv); err == nil {
+			return tzEnv
+		}
+	}
+
+	// /etc/localtime is usually a symlink into the zoneinfo database
+	// (check the direct target first; the fully resolved one may be an alias)
+	if target, err := os.Readlink(localtimePath); err == nil {
+		if name := zoneFromPath(target); name != "" {
+			return name
+		}
+	}
+	if target, err := filepath.EvalSymlinks(localtimePath); err == nil {
+		if name := zoneFromPath(target); name != "" {
+			return name
+		}
+	}
+
+	// Debian-based systems also record the zone name in /etc/timezone
+	if data, err := os.ReadFile(timezoneFile); err == nil {
+		name := strings.TrimSpace(string(data))
+		if _, err := time.LoadLocation(name); name != "" && err == nil {
+			return name
+		}
+	}
+
+	return "UTC"
+}
+
+// zoneFromPath extracts the zone name from a zoneinfo file path
+// (e.g., /usr/share/zoneinfo/Europe/London -> Europe/London)
+func zoneFromPath(path string) string {
+	const marker = "zoneinfo/"
+	idx := strings.LastIndex(path, marker)
+	if idx < 0 {
+		return ""
+	}
+	name := path[idx+len(marker):]
+	// Skip the posix/ and right/ variants of the database
+	name = strings.TrimPrefix(strings.TrimPrefix(name, "posix/"), "right/")
+	if _, err := time.LoadLocation(name); err != nil {
+		return ""
+	}
+	return name
+}
+
+// ToWindows converts an IANA zone name to the Windows name Graph expects
+func ToWindows(iana string) (string, bool) {
+	name, ok := ianaToWindows[iana]
+	return name, ok
+}
+
+// ToIANA converts a Windows zone name (as returned by Graph) to its IANA zone
+func ToIANA(windows string) (string, bool) {
+	name, ok := windowsToIANA[windows]
+	return name, ok
+}
+
+// Resolve converts a timezone setting to an IANA zone name.
+// Accepts "Local" (the system zone), IANA names and Windows names; IANA
+// names are kept as given, even where a Windows zone has the same name
+// ("UTC").
+func Resolve(name string) (string, error) {
+	if name == "" || name == "Local" {
+		return Local(), nil
+	}
+	_, err := time.LoadLocation(name)
+	if err == nil {
+		return name, nil
+	}
+	if iana, ok := ToIANA(name); ok {
+		return iana, nil
+	}
+	return "", fmt.Errorf("unknown timezone %q: %w", name, err)
+}
+
+// LoadLocation loads a location from either an IANA or a Windows zone name
+func LoadLocation(name string) (*time.Location, error) {
+	loc, err := time.LoadLocation(name)
+	if err == nil {
+		return loc, nil
+	}
+	if iana, ok := ToIANA(name); ok {
+		return time.LoadLocation(iana)
+	}
+	return nil, err
+}
@@ -0,0 +1,141 @@
+package timezone
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestToWindows verifies IANA to Windows conversion including aliases
+func TestToWindows(t *testing.T) {
+	tests := map[string]string{
+		"Europe/London":       "GMT Standard Time",
+		"Europe/Paris":        "Romance Standard Time",
+		"Europe/Berlin":       "W. Europe Standard Time",
+		"America/New_York":    "Eastern Standard Time",
+		"America/Los_Angeles": "Pacific Standard Time",
+		"Asia/Kolkata":        "India Standard Time",
+		"Asia/Calcutta":       "India Standard Time",
+		"Australia/Melbourne": "AUS Eastern Standard Time",
+		"UTC":                 "UTC",
+		"Etc/UTC":             "UTC",
+	}
+
+	for iana, want := range tests {
+		got, ok := ToWindows(iana)
+		if !ok || got != want {
+			t.Errorf("ToWindows(%q) = %q, %v; want %q", iana, got, ok, want)
+		}
+	}
+
+	if _, ok := ToWindows("BST"); ok {
+		t.Error("Expected abbreviation BST to be unmapped")
+	}
+}
+
+// TestToIANA verifies Windows to IANA conversion
+func TestToIANA(t *testing.T) {
+	tests := map[string]string{
+		"GMT Standard Time":       "Europe/London",
+		"Romance Standard Time":   "Europe/Paris",
+		"Eastern Standard Time":   "America/New_York",
+		"India Standard Time":     "Asia/Kolkata",
+		"W. Europe Standard Time": "Europe/Berlin",
+		"UTC":                     "Etc/UTC",
+	}
+
+	for windows, want := range tests {
+		got, ok := ToIANA(windows)
+		if !ok || got != want {
+			t.Errorf("ToIANA(%q) = %q, %v; want %q", windows, got, ok, want)
+		}
+	}
+}
+
+// TestMappingsLoad verifies every mapped IANA zone exists in the tz database
+// and every Windows name round-trips
+func TestMappingsLoad(t *testing.T) {
+	for windows, iana := range windowsToIANA {
+		if _, err := LoadLocation(iana); err != nil {
+			t.Errorf("%s maps to unloadable zone %s: %v", windows, iana, err)
+		}
+		if back, ok := ToWindows(iana); !ok || back != windows {
+			t.Errorf("%s -> %s -> %q does not round-trip", windows, iana, back)
+		}
+	}
+	for iana := range ianaToWindows {
+		if _, err := LoadLocation(iana); err != nil {
+			t.Errorf("Unloadable IANA zone %s: %v", iana, err)
+		}
+	}
+}
+
+// TestResolve verifies timezone settings resolve to IANA names
+func TestResolve(t *testing.T) {
+	t.Setenv("TZ", "Europe/Paris")
+
+	tests := map[string]string{
+		"Local":                 "Europe/Paris",
+		"":                      "Europe/Paris",
+		"America/New_York":      "America/New_York",
+		"UTC":                   "UTC",
+		"Pacific Standard Time": "America/Los_Angeles",
+	}
+	for input, want := range tests {
+		got, err := Resolve(input)
+		if err != nil || got != want {
+			t.Errorf("Resolve(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+
+	if _, err := Resolve("Not/A_Zone"); err == nil {
+		t.Error("Expected error for unknown timezone")
+	}
+
+	if loc, err := LoadLocation("UTC"); err != nil || loc.String() != "UTC" {
+		t.Errorf("LoadLocation(UTC) = %v, %v; want UTC", loc, err)
+	}
+}
+
+// TestLocalFrom verifies system timezone detection sources and priority
+func TestLocalFrom(t *testing.T) {
+	dir := t.TempDir()
+
+	// Symlinked /etc/localtime
+	localtime := filepath.Join(dir, "localtime")
+	if err := os.Symlink("/usr/share/zoneinfo/America/Chicago", localtime); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+
+	// /etc/timezone file
+	timezoneFile := filepath.Join(dir, "timezone")
+	if err := os.WriteFile(timezoneFile, []byte("Asia/Tokyo\n"), 0644); err != nil {
+		t.Fatalf("Failed to write timezone file: %v", err)
+	}
+
+	missing := filepath.Join(dir, "missing")
+
+	tests := []struct {
+		name      string
+		tzEnv     string
+		localtime string
+		tzFile    string
+		want      string
+	}{
+		{"TZ name wins", "Europe/London", localtime, timezoneFile, "Europe/London"},
+		{"TZ with colon prefix", ":Europe/Berlin", localtime, timezoneFile, "Europe/Berlin"},
+		{"TZ as zoneinfo path", "/usr/share/zoneinfo/Asia/Singapore", localtime, timezoneFile, "Asia/Singapore"},
+		{"invalid TZ falls through", "Nowhere/Land", localtime, timezoneFile, "America/Chicago"},
+		{"localtime symlink", "", localtime, timezoneFile, "America/Chicago"},
+		{"timezone file", "", missing, timezoneFile, "Asia/Tokyo"},
+		{"default UTC", "", missing, missing, "UTC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := localFrom(tt.tzEnv, tt.localtime, tt.tzFile); got != tt.want {
+				t.Errorf("localFrom() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
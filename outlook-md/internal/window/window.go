@@ -0,0 +1,196 @@
+package window
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Window is a half-open [Start, End) interval of whole calendar days
+type Window struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Expr resolves to a window relative to now (expressed in the target timezone)
+type Expr func(now time.Time) Window
+
+// dayOffsetPattern matches relative day offsets such as "+3d" or "-2d"
+var dayOffsetPattern = regexp.MustCompile(`^([+-])(\d+)d$`)
+
+// weekdays maps full and abbreviated weekday names
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+// ParseWeekday parses a full or abbreviated weekday name (e.g., "monday", "sun")
+func ParseWeekday(name string) (time.Weekday, error) {
+	wd, ok := weekdays[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return 0, fmt.Errorf("invalid weekday: %q", name)
+	}
+	return wd, nil
+}
+
+// StartOfDay returns the first instant of the calendar day in loc.
+// When midnight falls inside a DST gap the day starts at the transition.
+func StartOfDay(year int, month time.Month, day int, loc *time.Location) time.Time {
+	t := time.Date(year, month, day, 0, 0, 0, 0, loc)
+
+	// Normalize the requested date (day may be out of range, e.g. 32)
+	wantY, wantM, wantD := time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Date()
+	if y, m, d := t.Date(); y != wantY || m != wantM || d != wantD {
+		// time.Date resolved the missing midnight using the pre-transition
+		// offset, which lands on the previous day; shift by the gap
+		_, before := t.Zone()
+		_, after := t.Add(12 * time.Hour).Zone()
+		t = t.Add(time.Duration(after-before) * time.Second)
+	}
+
+	return t
+}
+
+// Day returns the window covering one calendar day in loc
+func Day(year int, month time.Month, day int, loc *time.Location) Window {
+	return Window{
+		Start: StartOfDay(year, month, day, loc),
+		End:   StartOfDay(year, month, day+1, loc),
+	}
+}
+
+// Week returns the seven-day window containing the given date,
+// starting on weekStart (typically time.Monday or time.Sunday)
+func Week(year int, month time.Month, day int, loc *time.Location, weekStart time.Weekday) Window {
+	weekday := time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday()
+	first := day - (int(weekday)-int(weekStart)+7)%7
+	return Window{
+		Start: StartOfDay(year, month, first, loc),
+		End:   StartOfDay(year, month, first+7, loc),
+	}
+}
+
+// Month returns the window covering one calendar month in loc
+func Month(year int, month time.Month, loc *time.Location) Window {
+	return Window{
+		Start: StartOfDay(year, month, 1, loc),
+		End:   StartOfDay(year, month+1, 1, loc),
+	}
+}
+
+// Parse parses a date expression:
+//   - today, tomorrow, yesterday
+//   - week, last-week, next-week, month, last-month, next-month
+//   - +Nd / -Nd (day relative to today)
+//   - weekday names, optionally prefixed by "this", "next" or "last"
+//   - ISO calendar dates (2026-10-01)
+//
+// Words may be separated by spaces or hyphens ("next friday", "next-week").
+// Week expressions start on weekStart.
+func Parse(expr string, weekStart time.Weekday) (Expr, error) {
+	trimmed := strings.TrimSpace(expr)
+
+	// Relative day offsets (+3d, -2d)
+	if m := dayOffsetPattern.FindStringSubmatch(trimmed); m != nil {
+		n, err := strconv.Atoi(m[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid day offset: %s", expr)
+		}
+		if m[1] == "-" {
+			n = -n
+		}
+		return dayAt(n), nil
+	}
+
+	// Absolute calendar dates
+	if d, err := time.Parse("2006-01-02", trimmed); err == nil {
+		return func(now time.Time) Window {
+			return Day(d.Year(), d.Month(), d.Day(), now.Location())
+		}, nil
+	}
+
+	words := strings.Fields(strings.ToLower(strings.ReplaceAll(trimmed, "-", " ")))
+	switch strings.Join(words, " ") {
+	case "today":
+		return dayAt(0), nil
+	case "tomorrow":
+		return dayAt(1), nil
+	case "yesterday":
+		return dayAt(-1), nil
+	case "week", "this week":
+		return weekAt(0, weekStart), nil
+	case "next week":
+		return weekAt(1, weekStart), nil
+	case "last week":
+		return weekAt(-1, weekStart), nil
+	case "month", "this month":
+		return monthAt(0), nil
+	case "next month":
+		return monthAt(1), nil
+	case "last month":
+		return monthAt(-1), nil
+	}
+
+	// Weekday names with optional modifier
+	if len(words) == 1 || len(words) == 2 {
+		modifier, name := "this", words[len(words)-1]
+		if len(words) == 2 {
+			modifier = words[0]
+		}
+		if wd, ok := weekdays[name]; ok {
+			switch modifier {
+			case "this", "next", "last":
+				return weekdayAt(wd, modifier), nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("unrecognized date expression: %q", expr)
+}
+
+// dayAt resolves to the day offset calendar days from today
+func dayAt(offset int) Expr {
+	return func(now time.Time) Window {
+		return Day(now.Year(), now.Month(), now.Day()+offset, now.Location())
+	}
+}
+
+// weekAt resolves to the week offset weeks from the current one
+func weekAt(offset int, weekStart time.Weekday) Expr {
+	return func(now time.Time) Window {
+		return Week(now.Year(), now.Month(), now.Day()+7*offset, now.Location(), weekStart)
+	}
+}
+
+// monthAt resolves to the calendar month offset months from the current one
+func monthAt(offset int) Expr {
+	return func(now time.Time) Window {
+		return Month(now.Year(), now.Month()+time.Month(offset), now.Location())
+	}
+}
+
+// weekdayAt resolves to a named weekday:
+// "this" is today or the coming one, "next" is strictly after today
+// and "last" is strictly before today
+func weekdayAt(wd time.Weekday, modifier string) Expr {
+	return func(now time.Time) Window {
+		ahead := (int(wd) - int(now.Weekday()) + 7) % 7
+		offset := ahead
+		switch modifier {
+		case "next":
+			if ahead == 0 {
+				offset = 7
+			}
+		case "last":
+			offset = ahead - 7
+		}
+		return Day(now.Year(), now.Month(), now.Day()+offset, now.Location())
+	}
+}
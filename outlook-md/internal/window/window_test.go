@@ -0,0 +1,227 @@
+package window
+
+import (
+	"testing"
+	"time"
+)
+
+// mustLoad loads a timezone or fails the test
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("Failed to load timezone %s: %v", name, err)
+	}
+	return loc
+}
+
+// TestDayAcrossDST verifies day windows on DST transition days span 23/25 hours
+func TestDayAcrossDST(t *testing.T) {
+	tests := []struct {
+		name     string
+		zone     string
+		date     time.Time
+		duration time.Duration
+	}{
+		{"London spring forward", "Europe/London", time.Date(2026, 3, 29, 0, 0, 0, 0, time.UTC), 23 * time.Hour},
+		{"London fall back", "Europe/London", time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC), 25 * time.Hour},
+		{"New York spring forward", "America/New_York", time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC), 23 * time.Hour},
+		{"New York fall back", "America/New_York", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), 25 * time.Hour},
+		{"regular day", "Europe/London", time.Date(2026, 1, 7, 0, 0, 0, 0, time.UTC), 24 * time.Hour},
+		{"UTC", "UTC", time.Date(2026, 3, 29, 0, 0, 0, 0, time.UTC), 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc := mustLoad(t, tt.zone)
+			w := Day(tt.date.Year(), tt.date.Month(), tt.date.Day(), loc)
+
+			if got := w.End.Sub(w.Start); got != tt.duration {
+				t.Errorf("Expected %v window, got %v (%v - %v)", tt.duration, got, w.Start, w.End)
+			}
+			if w.Start.Hour() != 0 || w.Start.Minute() != 0 {
+				t.Errorf("Expected start at local midnight, got %v", w.Start)
+			}
+			if w.End.Hour() != 0 || w.End.Day() != tt.date.Day()+1 {
+				t.Errorf("Expected end at next local midnight, got %v", w.End)
+			}
+		})
+	}
+}
+
+// TestStartOfDayMidnightGap verifies days whose midnight is skipped by DST
+// start at the transition rather than on the previous day
+func TestStartOfDayMidnightGap(t *testing.T) {
+	// Chile springs forward at 00:00 -> 01:00 on the first Sunday of September
+	loc := mustLoad(t, "America/Santiago")
+
+	start := StartOfDay(2026, time.September, 6, loc)
+	if y, m, d := start.Date(); y != 2026 || m != time.September || d != 6 {
+		t.Fatalf("Expected start on 2026-09-06, got %v", start)
+	}
+	if start.Hour() != 1 || start.Minute() != 0 {
+		t.Errorf("Expected day to start at 01:00, got %v", start)
+	}
+
+	// The previous day must end exactly where this one starts
+	prev := Day(2026, time.September, 5, loc)
+	if !prev.End.Equal(start) {
+		t.Errorf("Expected previous day to end at %v, got %v", start, prev.End)
+	}
+	if got := prev.End.Sub(prev.Start); got != 24*time.Hour {
+		t.Errorf("Expected previous day to last 24h, got %v", got)
+	}
+}
+
+// TestWeekStart verifies week windows honor the configured first day of the week
+func TestWeekStart(t *testing.T) {
+	loc := mustLoad(t, "Europe/London")
+
+	tests := []struct {
+		name      string
+		day       int // day of October 2026
+		weekStart time.Weekday
+		wantStart int
+	}{
+		{"Monday week from Wednesday", 14, time.Monday, 12},
+		{"Monday week from Monday", 12, time.Monday, 12},
+		{"Monday week from Sunday", 18, time.Monday, 12},
+		{"Sunday week from Wednesday", 14, time.Sunday, 11},
+		{"Sunday week from Sunday", 18, time.Sunday, 18},
+		{"Sunday week from Saturday", 17, time.Sunday, 11},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := Week(2026, time.October, tt.day, loc, tt.weekStart)
+
+			if w.Start.Day() != tt.wantStart || w.Start.Weekday() != tt.weekStart {
+				t.Errorf("Expected week to start on Oct %d, got %v", tt.wantStart, w.Start)
+			}
+			if got := w.End.Sub(w.Start); got != 7*24*time.Hour {
+				t.Errorf("Expected 168h week, got %v", got)
+			}
+		})
+	}
+}
+
+// TestWeekAcrossDST verifies a week containing a DST transition ends at local midnight
+func TestWeekAcrossDST(t *testing.T) {
+	loc := mustLoad(t, "Europe/London")
+
+	// Week of Mon 2026-10-19 contains the fall-back on Sunday 2026-10-25
+	w := Week(2026, time.October, 21, loc, time.Monday)
+
+	if got := w.End.Sub(w.Start); got != 7*24*time.Hour+time.Hour {
+		t.Errorf("Expected 169h week, got %v", got)
+	}
+	if w.End.Hour() != 0 || w.End.Day() != 26 {
+		t.Errorf("Expected week to end at local midnight Oct 26, got %v", w.End)
+	}
+}
+
+// TestMonth verifies month windows including year rollover
+func TestMonth(t *testing.T) {
+	loc := mustLoad(t, "America/New_York")
+
+	w := Month(2026, time.December, loc)
+	if !w.Start.Equal(time.Date(2026, 12, 1, 0, 0, 0, 0, loc)) {
+		t.Errorf("Unexpected month start: %v", w.Start)
+	}
+	if !w.End.Equal(time.Date(2027, 1, 1, 0, 0, 0, 0, loc)) {
+		t.Errorf("Unexpected month end: %v", w.End)
+	}
+
+	// March contains the spring-forward transition
+	w = Month(2026, time.March, loc)
+	if got := w.End.Sub(w.Start); got != 31*24*time.Hour-time.Hour {
+		t.Errorf("Expected March to be 743h, got %v", got)
+	}
+}
+
+// TestParse verifies date expressions resolve to the expected windows
+func TestParse(t *testing.T) {
+	loc := mustLoad(t, "Europe/London")
+	// Wednesday 2026-10-14 15:30 local time
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, loc)
+
+	tests := []struct {
+		expr      string
+		weekStart time.Weekday
+		start     time.Time
+		end       time.Time
+	}{
+		{"today", time.Monday, time.Date(2026, 10, 14, 0, 0, 0, 0, loc), time.Date(2026, 10, 15, 0, 0, 0, 0, loc)},
+		{"tomorrow", time.Monday, time.Date(2026, 10, 15, 0, 0, 0, 0, loc), time.Date(2026, 10, 16, 0, 0, 0, 0, loc)},
+		{"yesterday", time.Monday, time.Date(2026, 10, 13, 0, 0, 0, 0, loc), time.Date(2026, 10, 14, 0, 0, 0, 0, loc)},
+		{"+3d", time.Monday, time.Date(2026, 10, 17, 0, 0, 0, 0, loc), time.Date(2026, 10, 18, 0, 0, 0, 0, loc)},
+		{"-20d", time.Monday, time.Date(2026, 9, 24, 0, 0, 0, 0, loc), time.Date(2026, 9, 25, 0, 0, 0, 0, loc)},
+		{"week", time.Monday, time.Date(2026, 10, 12, 0, 0, 0, 0, loc), time.Date(2026, 10, 19, 0, 0, 0, 0, loc)},
+		{"week", time.Sunday, time.Date(2026, 10, 11, 0, 0, 0, 0, loc), time.Date(2026, 10, 18, 0, 0, 0, 0, loc)},
+		{"next-week", time.Monday, time.Date(2026, 10, 19, 0, 0, 0, 0, loc), time.Date(2026, 10, 26, 0, 0, 0, 0, loc)},
+		{"last week", time.Monday, time.Date(2026, 10, 5, 0, 0, 0, 0, loc), time.Date(2026, 10, 12, 0, 0, 0, 0, loc)},
+		{"month", time.Monday, time.Date(2026, 10, 1, 0, 0, 0, 0, loc), time.Date(2026, 11, 1, 0, 0, 0, 0, loc)},
+		{"last-month", time.Monday, time.Date(2026, 9, 1, 0, 0, 0, 0, loc), time.Date(2026, 10, 1, 0, 0, 0, 0, loc)},
+		{"friday", time.Monday, time.Date(2026, 10, 16, 0, 0, 0, 0, loc), time.Date(2026, 10, 17, 0, 0, 0, 0, loc)},
+		{"wednesday", time.Monday, time.Date(2026, 10, 14, 0, 0, 0, 0, loc), time.Date(2026, 10, 15, 0, 0, 0, 0, loc)},
+		{"next wednesday", time.Monday, time.Date(2026, 10, 21, 0, 0, 0, 0, loc), time.Date(2026, 10, 22, 0, 0, 0, 0, loc)},
+		{"next friday", time.Monday, time.Date(2026, 10, 16, 0, 0, 0, 0, loc), time.Date(2026, 10, 17, 0, 0, 0, 0, loc)},
+		{"last Mon", time.Monday, time.Date(2026, 10, 12, 0, 0, 0, 0, loc), time.Date(2026, 10, 13, 0, 0, 0, 0, loc)},
+		{"2026-10-01", time.Monday, time.Date(2026, 10, 1, 0, 0, 0, 0, loc), time.Date(2026, 10, 2, 0, 0, 0, 0, loc)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := Parse(tt.expr, tt.weekStart)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tt.expr, err)
+			}
+			w := expr(now)
+			if !w.Start.Equal(tt.start) || !w.End.Equal(tt.end) {
+				t.Errorf("Parse(%q) = [%v, %v), want [%v, %v)", tt.expr, w.Start, w.End, tt.start, tt.end)
+			}
+		})
+	}
+}
+
+// TestParseTomorrowBeforeSpringForward verifies "tomorrow" is a calendar day,
+// not now+24h, which skips a day late in the evening before spring-forward
+func TestParseTomorrowBeforeSpringForward(t *testing.T) {
+	loc := mustLoad(t, "Europe/London")
+	now := time.Date(2026, 3, 28, 23, 30, 0, 0, loc)
+
+	expr, err := Parse("tomorrow", time.Monday)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	w := expr(now)
+
+	if w.Start.Day() != 29 {
+		t.Errorf("Expected tomorrow to be March 29, got %v", w.Start)
+	}
+	if got := w.End.Sub(w.Start); got != 23*time.Hour {
+		t.Errorf("Expected 23h window, got %v", got)
+	}
+}
+
+// TestParseInvalid verifies unrecognized expressions are rejected
+func TestParseInvalid(t *testing.T) {
+	for _, expr := range []string{"", "someday", "next", "+3x", "2026-13-01", "next fortnight", "after friday"} {
+		if _, err := Parse(expr, time.Monday); err == nil {
+			t.Errorf("Expected error for %q", expr)
+		}
+	}
+}
+
+// TestParseWeekday verifies weekday name parsing
+func TestParseWeekday(t *testing.T) {
+	if wd, err := ParseWeekday("Sunday"); err != nil || wd != time.Sunday {
+		t.Errorf("Expected Sunday, got %v (%v)", wd, err)
+	}
+	if wd, err := ParseWeekday("mon"); err != nil || wd != time.Monday {
+		t.Errorf("Expected Monday, got %v (%v)", wd, err)
+	}
+	if _, err := ParseWeekday("funday"); err == nil {
+		t.Error("Expected error for invalid weekday")
+	}
+}
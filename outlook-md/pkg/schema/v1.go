@@ -4,10 +4,13 @@ import "time"
 
 // CLIOutput represents the complete JSON output from the CLI (Version 1)
 type CLIOutput struct {
-	Version  int        `json:"version"`
-	Timezone string     `json:"timezone"`
-	Window   TimeWindow `json:"window"`
-	Events   []CalendarEvent `json:"events"`
+	Version   int             `json:"version"`
+	Timezone  string          `json:"timezone"`
+	Window    TimeWindow      `json:"window"`
+	Events    []CalendarEvent `json:"events"`
+	Removed   []RemovedEvent  `json:"removed,omitempty"`   // Events removed since the previous --delta sync
+	FetchedAt *time.Time      `json:"fetchedAt,omitempty"` // When the events were fetched from Graph
+	Stale     bool            `json:"stale,omitempty"`     // Served from the local cache instead of Graph
 }
 
 // TimeWindow represents the query time range
@@ -18,16 +21,170 @@ type TimeWindow struct {
 
 // CalendarEvent represents a single calendar event from Microsoft Graph
 type CalendarEvent struct {
-	ID        string     `json:"id"`
-	Subject   string     `json:"subject"`
-	IsAllDay  bool       `json:"isAllDay"`
-	Start     time.Time  `json:"start"`
-	End       time.Time  `json:"end"`
-	Location  string     `json:"location"`
-	Organizer Organizer  `json:"organizer"`
-	Attendees []Attendee `json:"attendees"`
+	ID             string       `json:"id"`
+	ICalUID        string       `json:"iCalUId"` // Identifier shared by copies of the event across calendars
+	Subject        string       `json:"subject"`
+	IsAllDay       bool         `json:"isAllDay"`
+	Start          time.Time    `json:"start"`
+	End            time.Time    `json:"end"`
+	Location       string       `json:"location"`
+	Organizer      Organizer    `json:"organizer"`
+	Attendees      []Attendee   `json:"attendees"`
+	Body           string       `json:"body"`               // Event body converted to markdown
+	BodyPreview    string       `json:"bodyPreview"`        // Plain-text preview from Graph
+	ResponseStatus string       `json:"responseStatus"`     // Signed-in user's response, e.g. "accepted"
+	Calendar       *CalendarRef `json:"calendar,omitempty"` // Source calendar when selected with --calendar or --user
+	Change         string       `json:"change,omitempty"`   // ChangeAdded or ChangeUpdated with --delta
+	Account        string       `json:"account,omitempty"`  // Account the event was read from when merging several with --account
+
+	IsOnlineMeeting       bool           `json:"isOnlineMeeting"`
+	OnlineMeetingProvider string         `json:"onlineMeetingProvider,omitempty"` // e.g. "teamsForBusiness"
+	OnlineMeeting         *OnlineMeeting `json:"onlineMeeting,omitempty"`         // Join details Graph holds for online meetings
+	JoinURL               string         `json:"joinUrl,omitempty"`               // onlineMeeting.joinUrl, else a meeting link found in the location or body
+
+	Type           string      `json:"type,omitempty"`           // EventTypeSingleInstance, EventTypeOccurrence, EventTypeException or EventTypeSeriesMaster
+	SeriesMasterID string      `json:"seriesMasterId,omitempty"` // Series master of an occurrence or exception
+	OriginalStart  *time.Time  `json:"originalStart,omitempty"`  // Start of an occurrence or exception as scheduled by its series
+	Recurrence     *Recurrence `json:"recurrence,omitempty"`     // Recurrence of the series the event belongs to
+
+	AttendeeResponses ResponseCounts `json:"attendeeResponses"` // Invitees' responses, counted before --hide-declined
+
+	Categories                 []string   `json:"categories,omitempty"`                 // Outlook categories, e.g. "Red category"
+	Importance                 string     `json:"importance,omitempty"`                 // "low", "normal" or "high"
+	Sensitivity                string     `json:"sensitivity,omitempty"`                // "normal", "personal", "private" or "confidential"
+	ShowAs                     string     `json:"showAs,omitempty"`                     // "free", "tentative", "busy", "oof", "workingElsewhere" or "unknown"
+	IsCancelled                bool       `json:"isCancelled"`                          // Cancelled by the organizer but still on the calendar
+	HasAttachments             bool       `json:"hasAttachments"`                       // The invite has files attached
+	ReminderMinutesBeforeStart *int       `json:"reminderMinutesBeforeStart,omitempty"` // Set only if the reminder is on
+	CreatedDateTime            *time.Time `json:"createdDateTime,omitempty"`
+	LastModifiedDateTime       *time.Time `json:"lastModifiedDateTime,omitempty"`
+	WebLink                    string     `json:"webLink,omitempty"` // Opens the event in Outlook on the web
+}
+
+// OnlineMeeting holds the details for joining an online meeting
+type OnlineMeeting struct {
+	JoinURL         string   `json:"joinUrl,omitempty"`
+	ConferenceID    string   `json:"conferenceId,omitempty"`
+	TollNumber      string   `json:"tollNumber,omitempty"`
+	TollFreeNumbers []string `json:"tollFreeNumbers,omitempty"`
+	QuickDial       string   `json:"quickDial,omitempty"` // Number with the conference ID, for one-tap dial-in
+	Phones          []Phone  `json:"phones,omitempty"`
+}
+
+// Phone is a dial-in number of an online meeting
+type Phone struct {
+	Number string `json:"number"`
+	Type   string `json:"type,omitempty"` // e.g. "business" or "mobile"
+}
+
+// Event types reported by Graph
+const (
+	EventTypeSingleInstance = "singleInstance"
+	EventTypeOccurrence     = "occurrence"
+	EventTypeException      = "exception" // An occurrence that was moved or edited
+	EventTypeSeriesMaster   = "seriesMaster"
+)
+
+// Recurrence describes how a recurring series repeats
+type Recurrence struct {
+	Pattern RecurrencePattern `json:"pattern"`
+	Range   RecurrenceRange   `json:"range"`
+	Summary string            `json:"summary"` // Human-readable summary, e.g. "Weekly on Tue, Thu"
+}
+
+// RecurrencePattern is the frequency of a series, as in Graph
+type RecurrencePattern struct {
+	Type           string   `json:"type"` // "daily", "weekly", "absoluteMonthly", "relativeMonthly", "absoluteYearly" or "relativeYearly"
+	Interval       int      `json:"interval"`
+	DaysOfWeek     []string `json:"daysOfWeek,omitempty"` // e.g. "tuesday"
+	DayOfMonth     int      `json:"dayOfMonth,omitempty"`
+	Month          int      `json:"month,omitempty"`
+	Index          string   `json:"index,omitempty"` // Week of the month for relative patterns: "first" to "fourth" or "last"
+	FirstDayOfWeek string   `json:"firstDayOfWeek,omitempty"`
+}
+
+// RecurrenceRange is the duration of a series, as in Graph
+type RecurrenceRange struct {
+	Type                string `json:"type"`                          // "endDate", "noEnd" or "numbered"
+	StartDate           string `json:"startDate"`                     // YYYY-MM-DD
+	EndDate             string `json:"endDate,omitempty"`             // YYYY-MM-DD, for "endDate"
+	NumberOfOccurrences int    `json:"numberOfOccurrences,omitempty"` // For "numbered"
+	RecurrenceTimeZone  string `json:"recurrenceTimeZone,omitempty"`
+}
+
+// Change values marking events that changed since the previous --delta sync
+const (
+	ChangeAdded   = "added"
+	ChangeUpdated = "updated"
+)
+
+// RemovedEvent identifies an event removed since the previous --delta sync
+type RemovedEvent struct {
+	ID       string       `json:"id"`
+	Subject  string       `json:"subject"`
+	Calendar *CalendarRef `json:"calendar,omitempty"`
+	Account  string       `json:"account,omitempty"`
+}
+
+// CalendarRef identifies the calendar an event was read from
+type CalendarRef struct {
+	ID    string `json:"id"` // Empty for a mailbox's default calendar
+	Name  string `json:"name"`
+	Owner string `json:"owner,omitempty"` // Mailbox read with --user
+}
+
+// CalendarList represents the JSON output of the calendars command (Version 1)
+type CalendarList struct {
+	Version   int        `json:"version"`
+	Calendars []Calendar `json:"calendars"`
 }
 
+// Calendar describes a calendar the user can read
+type Calendar struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color"` // Hex color (e.g. "#E07A5F"), or Graph's color name when unset
+	Owner     Organizer `json:"owner"`
+	CanEdit   bool      `json:"canEdit"`
+	IsDefault bool      `json:"isDefault"`
+}
+
+// CLIError is written to stderr as a single JSON line with --error-format json
+type CLIError struct {
+	Version int         `json:"version"`
+	Error   ErrorDetail `json:"error"`
+}
+
+// ErrorDetail describes why the CLI failed
+type ErrorDetail struct {
+	Kind     string            `json:"kind"`     // One of the ErrorKind values
+	ExitCode int               `json:"exitCode"` // Process exit code for Kind
+	Message  string            `json:"message"`
+	Graph    *GraphErrorDetail `json:"graph,omitempty"` // Set when Graph returned an error response
+}
+
+// GraphErrorDetail holds the error returned by Microsoft Graph
+type GraphErrorDetail struct {
+	Status     int    `json:"status"`
+	Code       string `json:"code,omitempty"`
+	Message    string `json:"message,omitempty"`
+	RequestID  string `json:"requestId,omitempty"`
+	RetryAfter int    `json:"retryAfter,omitempty"` // Seconds Graph asked to wait before retrying
+}
+
+// Error kinds and the exit codes they map to; the codes are stable so that
+// callers such as the Neovim plugin can react without parsing messages
+const (
+	ErrorKindGeneral    = "error"      // Exit code 1
+	ErrorKindUsage      = "usage"      // Exit code 2: invalid command, flag or argument
+	ErrorKindAuth       = "auth"       // Exit code 3: sign-in failed or the token was rejected
+	ErrorKindNetwork    = "network"    // Exit code 4: Graph unreachable or unavailable
+	ErrorKindThrottled  = "throttled"  // Exit code 5: Graph is throttling requests
+	ErrorKindPermission = "permission" // Exit code 6: access to a calendar or mailbox was denied
+	ErrorKindConfig     = "config"     // Exit code 7: missing or invalid configuration
+	ErrorKindParse      = "parse"      // Exit code 8: unexpected response from Graph
+)
+
 // Organizer represents the event organizer
 type Organizer struct {
 	Name  string `json:"name"`
@@ -36,9 +193,20 @@ type Organizer struct {
 
 // Attendee represents a single event attendee
 type Attendee struct {
-	Name  string `json:"name"`
-	Email string `json:"email"`
-	Type  string `json:"type"` // "required", "optional", or "resource"
+	Name         string     `json:"name"`
+	Email        string     `json:"email"`
+	Type         string     `json:"type"`                   // "required", "optional", or "resource"
+	Response     string     `json:"response"`               // e.g. "accepted", "tentativelyAccepted", "declined" or "none"
+	ResponseTime *time.Time `json:"responseTime,omitempty"` // When the attendee responded
+}
+
+// ResponseCounts tallies the responses of an event's invitees, leaving out
+// resources such as rooms
+type ResponseCounts struct {
+	Accepted   int `json:"accepted"`
+	Tentative  int `json:"tentative"`
+	Declined   int `json:"declined"`
+	NoResponse int `json:"noResponse"` // "none" or "notResponded"
 }
 
 // AttendeeType constants for type validation
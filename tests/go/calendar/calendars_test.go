@@ -0,0 +1,188 @@
+package calendar_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/obsidian-outlook-sync/outlook-md/internal/calendar"
+)
+
+func TestListCalendars(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/me/calendars" {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("Expected Authorization header with bearer token")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "" {
+			w.Write([]byte(`{
+				"value": [
+					{"id": "CAL-1", "name": "Calendar", "color": "auto", "hexColor": "", "canEdit": true, "isDefaultCalendar": true,
+					 "owner": {"name": "Me", "address": "me@example.com"}}
+				],
+				"@odata.nextLink": "` + server.URL + `/me/calendars?page=2"
+			}`))
+			return
+		}
+		w.Write([]byte(`{
+			"value": [
+				{"id": "CAL-2", "name": "Project X", "color": "lightBlue", "hexColor": "#E07A5F", "canEdit": false, "isDefaultCalendar": false,
+				 "owner": {"name": "Me", "address": "me@example.com"}}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL)
+	calendars, err := client.ListCalendars(context.Background())
+	if err != nil {
+		t.Fatalf("ListCalendars failed: %v", err)
+	}
+	if len(calendars) != 2 {
+		t.Fatalf("Expected 2 calendars across pages, got %d", len(calendars))
+	}
+
+	if calendars[0].ID != "CAL-1" || !calendars[0].IsDefault || !calendars[0].CanEdit || calendars[0].Color != "auto" {
+		t.Errorf("Unexpected first calendar: %+v", calendars[0])
+	}
+	if calendars[1].Name != "Project X" || calendars[1].Color != "#E07A5F" || calendars[1].CanEdit {
+		t.Errorf("Unexpected second calendar: %+v", calendars[1])
+	}
+	if calendars[1].Owner.Email != "me@example.com" {
+		t.Errorf("Expected owner email, got %q", calendars[1].Owner.Email)
+	}
+}
+
+func TestGetCalendarViewFor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/me/calendars/CAL-2/calendarView" {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"value": [
+				{
+					"id": "EVENT-1",
+					"iCalUId": "ICAL-1",
+					"subject": "Project Sync",
+					"isAllDay": false,
+					"start": {"dateTime": "2026-07-01T09:00:00.0000000", "timeZone": "UTC"},
+					"end": {"dateTime": "2026-07-01T10:00:00.0000000", "timeZone": "UTC"},
+					"location": {"displayName": ""},
+					"organizer": {"emailAddress": {"name": "Bob", "address": "bob@example.com"}},
+					"attendees": [{"emailAddress": {"name": "Me", "address": "me@example.com"}, "type": "required"}],
+					"responseStatus": {"response": "accepted"}
+				}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL)
+
+	start := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	src := calendar.Source{CalendarID: "CAL-2", Name: "Project X"}
+	events, err := client.GetCalendarViewFor(context.Background(), src, start, start.Add(24*time.Hour), "UTC")
+	if err != nil {
+		t.Fatalf("GetCalendarViewFor failed: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+
+	if events[0].ICalUID != "ICAL-1" {
+		t.Errorf("Expected iCalUId ICAL-1, got %q", events[0].ICalUID)
+	}
+	if events[0].Calendar == nil || events[0].Calendar.ID != "CAL-2" || events[0].Calendar.Name != "Project X" {
+		t.Errorf("Expected event tagged with Project X, got %+v", events[0].Calendar)
+	}
+}
+
+func TestGetCalendarViewFor_User(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users/alice@corp.com/calendarView" {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"value": [
+				{
+					"id": "EVENT-1",
+					"iCalUId": "ICAL-1",
+					"subject": "Board Meeting",
+					"isAllDay": false,
+					"start": {"dateTime": "2026-07-01T09:00:00.0000000", "timeZone": "UTC"},
+					"end": {"dateTime": "2026-07-01T10:00:00.0000000", "timeZone": "UTC"},
+					"location": {"displayName": ""},
+					"organizer": {"emailAddress": {"name": "Alice", "address": "alice@corp.com"}},
+					"attendees": [{"emailAddress": {"name": "Bob", "address": "bob@corp.com"}, "type": "required"}],
+					"responseStatus": {"response": "organizer"}
+				}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL)
+
+	start := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	src := calendar.Source{User: "alice@corp.com", Name: "alice@corp.com"}
+	events, err := client.GetCalendarViewFor(context.Background(), src, start, start.Add(24*time.Hour), "UTC")
+	if err != nil {
+		t.Fatalf("GetCalendarViewFor failed: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+
+	ref := events[0].Calendar
+	if ref == nil || ref.Owner != "alice@corp.com" || ref.ID != "" {
+		t.Errorf("Expected event tagged with alice's default calendar, got %+v", ref)
+	}
+}
+
+func TestGetCalendarViewFor_UserForbidden(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error": {"code": "ErrorAccessDenied", "message": "Access is denied."}}`))
+	}))
+	defer server.Close()
+
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL)
+
+	start := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	src := calendar.Source{User: "ceo@corp.com", Name: "ceo@corp.com"}
+	if _, err := client.GetCalendarViewFor(context.Background(), src, start, start.Add(24*time.Hour), "UTC"); err == nil {
+		t.Fatal("Expected error for calendar that is not shared")
+	}
+}
+
+func TestGraphError_Envelope(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error": {"code": "ErrorAccessDenied", "message": "Access is denied.", "innerError": {"request-id": "req-42", "date": "2026-07-01T09:00:00"}}}`))
+	}))
+	defer server.Close()
+
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL)
+	_, err := client.ListCalendars(context.Background())
+
+	var graphErr *calendar.GraphError
+	if !errors.As(err, &graphErr) {
+		t.Fatalf("Expected GraphError, got %v", err)
+	}
+	if graphErr.StatusCode != http.StatusForbidden || graphErr.Code != "ErrorAccessDenied" || graphErr.Message != "Access is denied." || graphErr.RequestID != "req-42" {
+		t.Errorf("Unexpected parsed error: %+v", graphErr)
+	}
+	if got := graphErr.Error(); got != "Graph API returned status 403 (ErrorAccessDenied): Access is denied." {
+		t.Errorf("Unexpected error message: %q", got)
+	}
+}
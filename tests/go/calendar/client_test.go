@@ -7,10 +7,12 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/obsidian-outlook-sync/outlook-md/internal/calendar"
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
 )
 
 // TestGetCalendarView_EmptyResponse tests fetching calendar when no events exist
@@ -38,7 +40,7 @@ func TestGetCalendarView_EmptyResponse(t *testing.T) {
 	defer server.Close()
 
 	// Create client with mock server URL
-	client := calendar.NewGraphClientWithBaseURL("test-token", server.URL)
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL)
 
 	// Execute request
 	ctx := context.Background()
@@ -70,7 +72,7 @@ func TestGetCalendarView_SingleEvent(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := calendar.NewGraphClientWithBaseURL("test-token", server.URL)
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL)
 
 	ctx := context.Background()
 	start := time.Date(2026, 1, 7, 0, 0, 0, 0, time.UTC)
@@ -138,7 +140,7 @@ func TestGetCalendarView_ManyEvents(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := calendar.NewGraphClientWithBaseURL("test-token", server.URL)
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL)
 
 	ctx := context.Background()
 	start := time.Date(2026, 1, 7, 0, 0, 0, 0, time.UTC)
@@ -174,7 +176,7 @@ func TestGetCalendarView_AllDayEvent(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := calendar.NewGraphClientWithBaseURL("test-token", server.URL)
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL)
 
 	ctx := context.Background()
 	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
@@ -244,7 +246,7 @@ func TestGetCalendarView_FilterOrganizerNoAttendees(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := calendar.NewGraphClientWithBaseURL("test-token", server.URL)
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL)
 
 	ctx := context.Background()
 	start := time.Date(2026, 1, 7, 0, 0, 0, 0, time.UTC)
@@ -269,7 +271,7 @@ func TestGetCalendarView_HTTPError(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := calendar.NewGraphClientWithBaseURL("invalid-token", server.URL)
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("invalid-token"), server.URL)
 
 	ctx := context.Background()
 	start := time.Now()
@@ -295,7 +297,7 @@ func TestGetCalendarView_MalformedJSON(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := calendar.NewGraphClientWithBaseURL("test-token", server.URL)
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL)
 
 	ctx := context.Background()
 	start := time.Now()
@@ -321,7 +323,7 @@ func TestGetCalendarView_Timeout(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := calendar.NewGraphClientWithBaseURL("test-token", server.URL)
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL)
 
 	// Create context with short timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
@@ -341,6 +343,59 @@ func TestGetCalendarView_Timeout(t *testing.T) {
 	}
 }
 
+// TestGetCalendarView_WindowsTimezone tests that IANA timezones are sent to Graph
+// as Windows names and that Windows names in responses are converted back
+func TestGetCalendarView_WindowsTimezone(t *testing.T) {
+	mockResponse := []byte(`{
+		"value": [
+			{
+				"id": "TZ-EVENT-001",
+				"subject": "Summer Sync",
+				"isAllDay": false,
+				"start": {"dateTime": "2026-07-01T09:00:00.0000000", "timeZone": "GMT Standard Time"},
+				"end": {"dateTime": "2026-07-01T10:00:00.0000000", "timeZone": "GMT Standard Time"},
+				"location": {"displayName": ""},
+				"organizer": {"emailAddress": {"name": "Alice", "address": "alice@example.com"}},
+				"attendees": [{"emailAddress": {"name": "Bob", "address": "bob@example.com"}, "type": "required"}],
+				"responseStatus": {"response": "accepted"}
+			}
+		]
+	}`)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Prefer"); got != `outlook.timezone="GMT Standard Time"` {
+			t.Errorf("Expected Windows timezone in Prefer header, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(mockResponse)
+	}))
+	defer server.Close()
+
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL)
+
+	ctx := context.Background()
+	start := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	events, err := client.GetCalendarView(ctx, start, end, "Europe/London")
+	if err != nil {
+		t.Fatalf("GetCalendarView failed: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+
+	// 09:00 BST is 08:00 UTC
+	want := time.Date(2026, 7, 1, 8, 0, 0, 0, time.UTC)
+	if !events[0].Start.Equal(want) {
+		t.Errorf("Expected start %v, got %v", want, events[0].Start)
+	}
+	if events[0].Start.Location().String() != "Europe/London" {
+		t.Errorf("Expected start in Europe/London, got %s", events[0].Start.Location())
+	}
+}
+
 // loadTestData loads test fixture from testdata directory
 func loadTestData(t *testing.T, filename string) []byte {
 	t.Helper()
@@ -385,3 +440,362 @@ func TestValidateTestFixtures(t *testing.T) {
 		})
 	}
 }
+
+// TestGetCalendarView_Body verifies HTML bodies are converted to markdown
+// and the plain-text preview is passed through
+func TestGetCalendarView_Body(t *testing.T) {
+	mockResponse := []byte(`{
+		"value": [
+			{
+				"id": "BODY-EVENT-001",
+				"subject": "Roadmap Review",
+				"isAllDay": false,
+				"start": {"dateTime": "2026-07-01T09:00:00.0000000", "timeZone": "UTC"},
+				"end": {"dateTime": "2026-07-01T10:00:00.0000000", "timeZone": "UTC"},
+				"location": {"displayName": ""},
+				"organizer": {"emailAddress": {"name": "Alice", "address": "alice@example.com"}},
+				"attendees": [{"emailAddress": {"name": "Bob", "address": "bob@example.com"}, "type": "required"}],
+				"bodyPreview": "Agenda: Demo, Q&A",
+				"body": {
+					"contentType": "html",
+					"content": "<html><body><p><b>Agenda:</b></p><ul><li>Demo of <a href=\"https://example.com/demo\">the build</a></li><li>Q&amp;A</li></ul><div>________________________________________________________________________________</div><div>Microsoft Teams meeting</div><div>Join on your computer, mobile app or room device</div><div><a href=\"https://teams.microsoft.com/l/meetup-join/abc\">Click here to join the meeting</a></div><div>________________________________________________________________________________</div></body></html>"
+				},
+				"responseStatus": {"response": "accepted"}
+			}
+		]
+	}`)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(mockResponse)
+	}))
+	defer server.Close()
+
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL)
+
+	start := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	events, err := client.GetCalendarView(context.Background(), start, start.Add(24*time.Hour), "UTC")
+	if err != nil {
+		t.Fatalf("GetCalendarView failed: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+
+	wantBody := "**Agenda:**\n- Demo of [the build](https://example.com/demo)\n- Q&A"
+	if events[0].Body != wantBody {
+		t.Errorf("Expected body %q, got %q", wantBody, events[0].Body)
+	}
+	if events[0].BodyPreview != "Agenda: Demo, Q&A" {
+		t.Errorf("Expected body preview to pass through, got %q", events[0].BodyPreview)
+	}
+}
+
+// TestGetCalendarView_WithFilter verifies a custom filter keeps tentative
+// meetings and solo events and exposes the response status
+func TestGetCalendarView_WithFilter(t *testing.T) {
+	mockResponse := []byte(`{
+		"value": [
+			{
+				"id": "TENTATIVE-001",
+				"subject": "Maybe Meeting",
+				"isAllDay": false,
+				"start": {"dateTime": "2026-07-01T09:00:00.0000000", "timeZone": "UTC"},
+				"end": {"dateTime": "2026-07-01T10:00:00.0000000", "timeZone": "UTC"},
+				"location": {"displayName": ""},
+				"organizer": {"emailAddress": {"name": "Bob", "address": "bob@example.com"}},
+				"attendees": [{"emailAddress": {"name": "Me", "address": "me@example.com"}, "type": "required"}],
+				"responseStatus": {"response": "tentativelyAccepted"}
+			},
+			{
+				"id": "FOCUS-001",
+				"subject": "Focus Time",
+				"isAllDay": false,
+				"start": {"dateTime": "2026-07-01T11:00:00.0000000", "timeZone": "UTC"},
+				"end": {"dateTime": "2026-07-01T12:00:00.0000000", "timeZone": "UTC"},
+				"location": {"displayName": ""},
+				"organizer": {"emailAddress": {"name": "Me", "address": "me@example.com"}},
+				"attendees": [],
+				"responseStatus": {"response": "organizer"}
+			},
+			{
+				"id": "DECLINED-001",
+				"subject": "Declined Meeting",
+				"isAllDay": false,
+				"start": {"dateTime": "2026-07-01T13:00:00.0000000", "timeZone": "UTC"},
+				"end": {"dateTime": "2026-07-01T14:00:00.0000000", "timeZone": "UTC"},
+				"location": {"displayName": ""},
+				"organizer": {"emailAddress": {"name": "Bob", "address": "bob@example.com"}},
+				"attendees": [{"emailAddress": {"name": "Me", "address": "me@example.com"}, "type": "required"}],
+				"responseStatus": {"response": "declined"}
+			}
+		]
+	}`)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(mockResponse)
+	}))
+	defer server.Close()
+
+	filter := calendar.Filter{
+		ResponseStatuses: []string{calendar.ResponseOrganizer, calendar.ResponseAccepted, calendar.ResponseTentativelyAccepted},
+		IncludeSolo:      true,
+	}
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL, calendar.WithFilter(filter))
+
+	start := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	events, err := client.GetCalendarView(context.Background(), start, start.Add(24*time.Hour), "UTC")
+	if err != nil {
+		t.Fatalf("GetCalendarView failed: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events (tentative and solo), got %d", len(events))
+	}
+
+	if events[0].ID != "TENTATIVE-001" || events[0].ResponseStatus != "tentativelyAccepted" {
+		t.Errorf("Expected tentative event first, got %s (%s)", events[0].ID, events[0].ResponseStatus)
+	}
+	if events[1].ID != "FOCUS-001" || events[1].ResponseStatus != "organizer" {
+		t.Errorf("Expected solo event second, got %s (%s)", events[1].ID, events[1].ResponseStatus)
+	}
+}
+
+// TestGetCalendarView_OnlineMeeting verifies online meeting details and the
+// join link fallback for meetings of other services
+func TestGetCalendarView_OnlineMeeting(t *testing.T) {
+	mockResponse := []byte(`{
+		"value": [
+			{
+				"id": "TEAMS-001",
+				"subject": "Teams Meeting",
+				"start": {"dateTime": "2026-07-01T09:00:00.0000000", "timeZone": "UTC"},
+				"end": {"dateTime": "2026-07-01T09:30:00.0000000", "timeZone": "UTC"},
+				"organizer": {"emailAddress": {"name": "Alice", "address": "alice@example.com"}},
+				"responseStatus": {"response": "accepted"},
+				"isOnlineMeeting": true,
+				"onlineMeetingProvider": "teamsForBusiness",
+				"onlineMeeting": {
+					"joinUrl": "https://teams.microsoft.com/l/meetup-join/19%3ameeting_abc/0",
+					"conferenceId": "123456789",
+					"tollNumber": "+1 555 0100",
+					"tollFreeNumbers": ["+1 800 555 0100"],
+					"quickDial": "+15550100,,123456789#",
+					"phones": [{"number": "+44 20 7946 0000", "type": "business"}]
+				}
+			},
+			{
+				"id": "ZOOM-001",
+				"subject": "Zoom Meeting",
+				"start": {"dateTime": "2026-07-01T10:00:00.0000000", "timeZone": "UTC"},
+				"end": {"dateTime": "2026-07-01T10:30:00.0000000", "timeZone": "UTC"},
+				"organizer": {"emailAddress": {"name": "Bob", "address": "bob@example.com"}},
+				"responseStatus": {"response": "accepted"},
+				"body": {"contentType": "html", "content": "<p>Join: <a href=\"https://us02web.zoom.us/j/123?pwd=x&amp;from=addon\">Zoom</a></p>"},
+				"isOnlineMeeting": false,
+				"onlineMeetingProvider": "unknown",
+				"onlineMeeting": null
+			}
+		]
+	}`)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(mockResponse)
+	}))
+	defer server.Close()
+
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL)
+	start := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	events, err := client.GetCalendarView(context.Background(), start, start.Add(24*time.Hour), "UTC")
+	if err != nil {
+		t.Fatalf("GetCalendarView failed: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+
+	teams := events[0]
+	if !teams.IsOnlineMeeting || teams.OnlineMeetingProvider != "teamsForBusiness" || teams.OnlineMeeting == nil {
+		t.Fatalf("Expected Teams meeting details, got %+v", teams)
+	}
+	if teams.JoinURL != "https://teams.microsoft.com/l/meetup-join/19%3ameeting_abc/0" || teams.OnlineMeeting.JoinURL != teams.JoinURL {
+		t.Errorf("Unexpected join URL %q", teams.JoinURL)
+	}
+	om := teams.OnlineMeeting
+	if om.ConferenceID != "123456789" || om.TollNumber != "+1 555 0100" || len(om.TollFreeNumbers) != 1 || om.QuickDial == "" {
+		t.Errorf("Unexpected dial-in details: %+v", om)
+	}
+	if len(om.Phones) != 1 || om.Phones[0].Number != "+44 20 7946 0000" || om.Phones[0].Type != "business" {
+		t.Errorf("Unexpected phones: %+v", om.Phones)
+	}
+
+	zoom := events[1]
+	if zoom.IsOnlineMeeting || zoom.OnlineMeetingProvider != "" || zoom.OnlineMeeting != nil {
+		t.Errorf("Expected no Graph online meeting, got %+v", zoom)
+	}
+	if zoom.JoinURL != "https://us02web.zoom.us/j/123?pwd=x&from=addon" {
+		t.Errorf("Expected Zoom link from the body, got %q", zoom.JoinURL)
+	}
+}
+
+func TestGetCalendarView_AttendeeResponses(t *testing.T) {
+	mockResponse := []byte(`{
+		"value": [
+			{
+				"id": "PLAN-001",
+				"subject": "Planning",
+				"start": {"dateTime": "2026-07-01T09:00:00.0000000", "timeZone": "UTC"},
+				"end": {"dateTime": "2026-07-01T10:00:00.0000000", "timeZone": "UTC"},
+				"organizer": {"emailAddress": {"name": "Alice", "address": "alice@example.com"}},
+				"responseStatus": {"response": "organizer"},
+				"attendees": [
+					{"emailAddress": {"name": "Bob", "address": "bob@example.com"}, "type": "required",
+						"status": {"response": "accepted", "time": "2026-06-28T14:30:00Z"}},
+					{"emailAddress": {"name": "Carol", "address": "carol@example.com"}, "type": "required",
+						"status": {"response": "declined", "time": "2026-06-29T08:00:00Z"}},
+					{"emailAddress": {"name": "Dave", "address": "dave@example.com"}, "type": "optional",
+						"status": {"response": "none", "time": "0001-01-01T00:00:00Z"}},
+					{"emailAddress": {"name": "Room 1", "address": "room1@example.com"}, "type": "resource",
+						"status": {"response": "accepted", "time": "2026-06-28T12:00:00Z"}}
+				]
+			}
+		]
+	}`)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(mockResponse)
+	}))
+	defer server.Close()
+
+	start := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL)
+	events, err := client.GetCalendarView(context.Background(), start, start.Add(24*time.Hour), "Europe/London")
+	if err != nil {
+		t.Fatalf("GetCalendarView failed: %v", err)
+	}
+	if len(events) != 1 || len(events[0].Attendees) != 4 {
+		t.Fatalf("Expected 1 event with 4 attendees, got %+v", events)
+	}
+
+	bob := events[0].Attendees[0]
+	if bob.Email != "bob@example.com" || bob.Response != "accepted" {
+		t.Errorf("Unexpected attendee: %+v", bob)
+	}
+	if bob.ResponseTime == nil || !bob.ResponseTime.Equal(time.Date(2026, 6, 28, 14, 30, 0, 0, time.UTC)) || bob.ResponseTime.Location().String() != "Europe/London" {
+		t.Errorf("Expected response time in the requested timezone, got %v", bob.ResponseTime)
+	}
+	if dave := events[0].Attendees[2]; dave.Response != "none" || dave.ResponseTime != nil {
+		t.Errorf("Expected no response time without a response, got %+v", dave)
+	}
+	want := schema.ResponseCounts{Accepted: 1, Declined: 1, NoResponse: 1}
+	if events[0].AttendeeResponses != want {
+		t.Errorf("AttendeeResponses = %+v, want %+v", events[0].AttendeeResponses, want)
+	}
+
+	// Hiding declined attendees keeps them in the counts
+	filter := calendar.DefaultFilter()
+	filter.HideDeclined = true
+	client = calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL, calendar.WithFilter(filter))
+	events, err = client.GetCalendarView(context.Background(), start, start.Add(24*time.Hour), "UTC")
+	if err != nil {
+		t.Fatalf("GetCalendarView failed: %v", err)
+	}
+	for _, a := range events[0].Attendees {
+		if a.Response == "declined" {
+			t.Errorf("Expected declined attendee %s to be hidden", a.Email)
+		}
+	}
+	if len(events[0].Attendees) != 3 || events[0].AttendeeResponses != want {
+		t.Errorf("Unexpected attendees %+v or counts %+v", events[0].Attendees, events[0].AttendeeResponses)
+	}
+}
+
+func TestGetCalendarView_Metadata(t *testing.T) {
+	mockResponse := []byte(`{
+		"value": [
+			{
+				"id": "META-001",
+				"subject": "Quarterly Review",
+				"start": {"dateTime": "2026-07-01T09:00:00.0000000", "timeZone": "UTC"},
+				"end": {"dateTime": "2026-07-01T10:00:00.0000000", "timeZone": "UTC"},
+				"organizer": {"emailAddress": {"name": "Alice", "address": "alice@example.com"}},
+				"responseStatus": {"response": "accepted"},
+				"categories": ["Red category", "Project X"],
+				"importance": "high",
+				"sensitivity": "private",
+				"showAs": "workingElsewhere",
+				"isCancelled": true,
+				"hasAttachments": true,
+				"isReminderOn": true,
+				"reminderMinutesBeforeStart": 15,
+				"createdDateTime": "2026-06-20T08:15:30.1234567Z",
+				"lastModifiedDateTime": "2026-06-25T16:45:00.0000000Z",
+				"webLink": "https://outlook.office365.com/owa/?itemid=META-001&exvsurl=1&path=/calendar/item"
+			},
+			{
+				"id": "META-002",
+				"subject": "No Reminder",
+				"start": {"dateTime": "2026-07-01T11:00:00.0000000", "timeZone": "UTC"},
+				"end": {"dateTime": "2026-07-01T12:00:00.0000000", "timeZone": "UTC"},
+				"organizer": {"emailAddress": {"name": "Alice", "address": "alice@example.com"}},
+				"responseStatus": {"response": "accepted"},
+				"isReminderOn": false,
+				"reminderMinutesBeforeStart": 15
+			}
+		]
+	}`)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Only the fields the client uses are requested
+		selected := map[string]bool{}
+		for _, field := range strings.Split(r.URL.Query().Get("$select"), ",") {
+			selected[field] = true
+		}
+		for _, field := range []string{"id", "start", "attendees", "body", "onlineMeeting", "seriesMasterId", "recurrence", "categories", "importance", "sensitivity", "showAs", "isCancelled", "hasAttachments", "isReminderOn", "reminderMinutesBeforeStart", "createdDateTime", "lastModifiedDateTime", "webLink"} {
+			if !selected[field] {
+				t.Errorf("Expected %s in $select, got %q", field, r.URL.Query().Get("$select"))
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(mockResponse)
+	}))
+	defer server.Close()
+
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL)
+	start := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	events, err := client.GetCalendarView(context.Background(), start, start.Add(24*time.Hour), "UTC")
+	if err != nil {
+		t.Fatalf("GetCalendarView failed: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+
+	event := events[0]
+	if len(event.Categories) != 2 || event.Categories[0] != "Red category" || event.Importance != "high" || event.Sensitivity != "private" || event.ShowAs != "workingElsewhere" {
+		t.Errorf("Unexpected metadata: %+v", event)
+	}
+	if !event.IsCancelled || !event.HasAttachments {
+		t.Errorf("Expected cancelled event with attachments, got %+v", event)
+	}
+	if event.ReminderMinutesBeforeStart == nil || *event.ReminderMinutesBeforeStart != 15 {
+		t.Errorf("Expected 15-minute reminder, got %v", event.ReminderMinutesBeforeStart)
+	}
+	if event.CreatedDateTime == nil || !event.CreatedDateTime.Equal(time.Date(2026, 6, 20, 8, 15, 30, 123456700, time.UTC)) {
+		t.Errorf("Unexpected creation time %v", event.CreatedDateTime)
+	}
+	if event.LastModifiedDateTime == nil || !event.LastModifiedDateTime.Equal(time.Date(2026, 6, 25, 16, 45, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected modification time %v", event.LastModifiedDateTime)
+	}
+	if event.WebLink != "https://outlook.office365.com/owa/?itemid=META-001&exvsurl=1&path=/calendar/item" {
+		t.Errorf("Unexpected web link %q", event.WebLink)
+	}
+
+	if events[1].ReminderMinutesBeforeStart != nil || events[1].CreatedDateTime != nil {
+		t.Errorf("Expected no reminder or creation time, got %+v", events[1])
+	}
+}
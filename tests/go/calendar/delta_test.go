@@ -0,0 +1,170 @@
+package calendar_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/obsidian-outlook-sync/outlook-md/internal/calendar"
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+// deltaEvent returns a Graph event for delta responses
+func deltaEvent(id, subject string, hour int) string {
+	return fmt.Sprintf(`{
+		"id": %q,
+		"iCalUId": "ICAL-%s",
+		"subject": %q,
+		"isAllDay": false,
+		"start": {"dateTime": "2026-07-01T%02d:00:00.0000000", "timeZone": "UTC"},
+		"end": {"dateTime": "2026-07-01T%02d:30:00.0000000", "timeZone": "UTC"},
+		"location": {"displayName": ""},
+		"organizer": {"emailAddress": {"name": "Bob", "address": "bob@example.com"}},
+		"attendees": [{"emailAddress": {"name": "Me", "address": "me@example.com"}, "type": "required"}],
+		"responseStatus": {"response": "accepted"}
+	}`, id, id, subject, hour, hour)
+}
+
+func TestGetCalendarViewDelta(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/me/calendarView/delta" {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+
+		query := r.URL.Query()
+		switch {
+		case query.Get("$deltatoken") == "round-1":
+			// Second sync: one update, one addition, one removal
+			w.Write([]byte(`{
+				"value": [
+					` + deltaEvent("EVENT-1", "Standup (moved)", 10) + `,
+					` + deltaEvent("EVENT-3", "Retro", 15) + `,
+					{"id": "EVENT-2", "@removed": {"reason": "deleted"}}
+				],
+				"@odata.deltaLink": "` + server.URL + `/me/calendarView/delta?$deltatoken=round-2"
+			}`))
+		case query.Get("$skiptoken") == "page-2":
+			w.Write([]byte(`{
+				"value": [` + deltaEvent("EVENT-2", "Planning", 13) + `],
+				"@odata.deltaLink": "` + server.URL + `/me/calendarView/delta?$deltatoken=round-1"
+			}`))
+		default:
+			// Initial sync
+			if query.Get("startDateTime") == "" || query.Get("endDateTime") == "" {
+				t.Errorf("Expected startDateTime and endDateTime on the initial request")
+			}
+			w.Write([]byte(`{
+				"value": [` + deltaEvent("EVENT-1", "Standup", 9) + `],
+				"@odata.nextLink": "` + server.URL + `/me/calendarView/delta?$skiptoken=page-2"
+			}`))
+		}
+	}))
+	defer server.Close()
+
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL)
+	start := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	first, err := client.GetCalendarViewDelta(context.Background(), calendar.Source{}, start, end, "UTC", nil)
+	if err != nil {
+		t.Fatalf("Initial delta failed: %v", err)
+	}
+	if len(first.Events) != 2 {
+		t.Fatalf("Expected 2 events across pages, got %d", len(first.Events))
+	}
+	for _, event := range first.Events {
+		if event.Change != schema.ChangeAdded {
+			t.Errorf("Expected %s to be added on initial sync, got %q", event.ID, event.Change)
+		}
+	}
+	if len(first.Removed) != 0 {
+		t.Errorf("Expected no removals on initial sync, got %+v", first.Removed)
+	}
+
+	second, err := client.GetCalendarViewDelta(context.Background(), calendar.Source{}, start, end, "UTC", first.State)
+	if err != nil {
+		t.Fatalf("Incremental delta failed: %v", err)
+	}
+	if len(second.Events) != 2 {
+		t.Fatalf("Expected 2 current events, got %d", len(second.Events))
+	}
+	if second.Events[0].ID != "EVENT-1" || second.Events[0].Subject != "Standup (moved)" || second.Events[0].Change != schema.ChangeUpdated {
+		t.Errorf("Expected updated standup first, got %+v", second.Events[0])
+	}
+	if second.Events[1].ID != "EVENT-3" || second.Events[1].Change != schema.ChangeAdded {
+		t.Errorf("Expected added retro second, got %+v", second.Events[1])
+	}
+	if len(second.Removed) != 1 || second.Removed[0].ID != "EVENT-2" || second.Removed[0].Subject != "Planning" {
+		t.Errorf("Expected removed planning event, got %+v", second.Removed)
+	}
+	if second.State.DeltaLink != server.URL+"/me/calendarView/delta?$deltatoken=round-2" {
+		t.Errorf("Expected new deltaLink, got %q", second.State.DeltaLink)
+	}
+}
+
+func TestGetCalendarViewDelta_Unchanged(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"value": [], "@odata.deltaLink": "` + server.URL + `/me/calendarView/delta?$deltatoken=next"}`))
+	}))
+	defer server.Close()
+
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL)
+	start := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	first, err := client.GetCalendarViewDelta(context.Background(), calendar.Source{}, start, start.Add(24*time.Hour), "UTC", nil)
+	if err != nil {
+		t.Fatalf("Initial delta failed: %v", err)
+	}
+	first.State.Events["EVENT-1"] = json.RawMessage(deltaEvent("EVENT-1", "Standup", 9))
+
+	second, err := client.GetCalendarViewDelta(context.Background(), calendar.Source{}, start, start.Add(24*time.Hour), "UTC", first.State)
+	if err != nil {
+		t.Fatalf("Incremental delta failed: %v", err)
+	}
+	if len(second.Events) != 1 || second.Events[0].Change != "" {
+		t.Errorf("Expected unchanged event from state without change mark, got %+v", second.Events)
+	}
+}
+
+func TestGetCalendarViewDelta_ExpiredState(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("$deltatoken") == "expired" {
+			w.WriteHeader(http.StatusGone)
+			w.Write([]byte(`{"error": {"code": "SyncStateNotFound", "message": "The sync state is no longer available."}}`))
+			return
+		}
+		w.Write([]byte(`{
+			"value": [` + deltaEvent("EVENT-1", "Standup", 9) + `],
+			"@odata.deltaLink": "` + server.URL + `/me/calendarView/delta?$deltatoken=fresh"
+		}`))
+	}))
+	defer server.Close()
+
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL)
+	start := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	state := &calendar.DeltaState{
+		DeltaLink: server.URL + "/me/calendarView/delta?$deltatoken=expired",
+		Events:    map[string]json.RawMessage{},
+	}
+	result, err := client.GetCalendarViewDelta(context.Background(), calendar.Source{}, start, start.Add(24*time.Hour), "UTC", state)
+	if err != nil {
+		t.Fatalf("Expected full resync after 410, got %v", err)
+	}
+	if len(result.Events) != 1 || result.Events[0].Change != schema.ChangeAdded {
+		t.Errorf("Expected resynced event marked added, got %+v", result.Events)
+	}
+	if result.State.DeltaLink != server.URL+"/me/calendarView/delta?$deltatoken=fresh" {
+		t.Errorf("Expected fresh deltaLink, got %q", result.State.DeltaLink)
+	}
+}
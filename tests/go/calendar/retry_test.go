@@ -0,0 +1,176 @@
+package calendar_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/obsidian-outlook-sync/outlook-md/internal/calendar"
+)
+
+// fastRetries retries quickly so tests don't wait on real backoff delays
+var fastRetries = calendar.RetryPolicy{
+	MaxAttempts: 3,
+	Budget:      10 * time.Second,
+	BaseDelay:   time.Millisecond,
+	MaxDelay:    10 * time.Millisecond,
+}
+
+func TestGetCalendarView_RetriesThrottling(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error": {"code": "TooManyRequests", "message": "Please retry again later."}}`))
+			return
+		}
+		w.Write([]byte(`{"value": [` + deltaEvent("EVENT-1", "Standup", 9) + `]}`))
+	}))
+	defer server.Close()
+
+	var waits []time.Duration
+	policy := fastRetries
+	policy.OnRetry = func(err error, wait time.Duration) { waits = append(waits, wait) }
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL, calendar.WithRetryPolicy(policy))
+
+	start := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	began := time.Now()
+	events, err := client.GetCalendarView(context.Background(), start, start.Add(24*time.Hour), "UTC")
+	if err != nil {
+		t.Fatalf("Expected success after retry, got %v", err)
+	}
+	if len(events) != 1 {
+		t.Errorf("Expected 1 event, got %d", len(events))
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests)
+	}
+	if len(waits) != 1 || waits[0] != time.Second {
+		t.Errorf("Expected a single 1s wait from Retry-After, got %v", waits)
+	}
+	if elapsed := time.Since(began); elapsed < time.Second {
+		t.Errorf("Expected Retry-After to be honored, retried after %v", elapsed)
+	}
+}
+
+func TestGetCalendarView_RetriesMidPagination(t *testing.T) {
+	var firstPage, secondPage int32
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "" {
+			atomic.AddInt32(&firstPage, 1)
+			w.Write([]byte(`{
+				"value": [` + deltaEvent("EVENT-1", "Standup", 9) + `],
+				"@odata.nextLink": "` + server.URL + `/me/calendarView?page=2"
+			}`))
+			return
+		}
+		if atomic.AddInt32(&secondPage, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"value": [` + deltaEvent("EVENT-2", "Planning", 13) + `]}`))
+	}))
+	defer server.Close()
+
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL, calendar.WithRetryPolicy(fastRetries))
+
+	start := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	events, err := client.GetCalendarView(context.Background(), start, start.Add(24*time.Hour), "UTC")
+	if err != nil {
+		t.Fatalf("Expected success after retrying the second page, got %v", err)
+	}
+	if len(events) != 2 || events[0].ID != "EVENT-1" || events[1].ID != "EVENT-2" {
+		t.Errorf("Expected events from both pages, got %+v", events)
+	}
+	if firstPage != 1 || secondPage != 2 {
+		t.Errorf("Expected only the failed page to be retried, got %d and %d requests", firstPage, secondPage)
+	}
+}
+
+func TestGetCalendarView_RetryLimits(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		retryAfter string
+		want       int32
+	}{
+		{"gives up after max attempts", http.StatusGatewayTimeout, "", 3},
+		{"does not retry client errors", http.StatusBadRequest, "", 1},
+		{"stops when Retry-After exceeds budget", http.StatusTooManyRequests, "120", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&requests, 1)
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL, calendar.WithRetryPolicy(fastRetries))
+
+			start := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+			_, err := client.GetCalendarView(context.Background(), start, start.Add(24*time.Hour), "UTC")
+
+			var graphErr *calendar.GraphError
+			if !errors.As(err, &graphErr) || graphErr.StatusCode != tt.status {
+				t.Fatalf("Expected Graph error with status %d, got %v", tt.status, err)
+			}
+			if requests != tt.want {
+				t.Errorf("Expected %d requests, got %d", tt.want, requests)
+			}
+		})
+	}
+}
+
+func TestGetCalendarView_RetryBudgetSpansPages(t *testing.T) {
+	var requests int32
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		// Both pages are throttled once before they are served
+		switch atomic.AddInt32(&requests, 1) {
+		case 1, 3:
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error": {"code": "TooManyRequests", "message": "Please retry again later."}}`))
+		case 2:
+			w.Write([]byte(`{
+				"value": [` + deltaEvent("EVENT-1", "Standup", 9) + `],
+				"@odata.nextLink": "` + server.URL + `/me/calendarView?page=2"
+			}`))
+		default:
+			w.Write([]byte(`{"value": [` + deltaEvent("EVENT-2", "Planning", 13) + `]}`))
+		}
+	}))
+	defer server.Close()
+
+	// Each wait fits the budget, but the second page's does not fit what
+	// the first page left of it
+	policy := fastRetries
+	policy.Budget = 1500 * time.Millisecond
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL, calendar.WithRetryPolicy(policy))
+
+	start := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	_, err := client.GetCalendarView(context.Background(), start, start.Add(24*time.Hour), "UTC")
+
+	var graphErr *calendar.GraphError
+	if !errors.As(err, &graphErr) || graphErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("Expected the throttled second page to fail the fetch, got %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("Expected 3 requests (page 1 twice, page 2 once), got %d", requests)
+	}
+}
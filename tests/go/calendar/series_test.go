@@ -0,0 +1,282 @@
+package calendar_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/obsidian-outlook-sync/outlook-md/internal/calendar"
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+// seriesMaster is the master of a weekly series on Tuesdays and Thursdays
+const seriesMaster = `{
+	"id": "MASTER-1",
+	"subject": "Team Sync",
+	"type": "seriesMaster",
+	"start": {"dateTime": "2026-01-06T09:00:00.0000000", "timeZone": "UTC"},
+	"end": {"dateTime": "2026-01-06T09:30:00.0000000", "timeZone": "UTC"},
+	"organizer": {"emailAddress": {"name": "Alice", "address": "alice@example.com"}},
+	"responseStatus": {"response": "organizer"},
+	"recurrence": {
+		"pattern": {"type": "weekly", "interval": 1, "month": 0, "dayOfMonth": 0, "daysOfWeek": ["tuesday", "thursday"], "firstDayOfWeek": "sunday", "index": "first"},
+		"range": {"type": "noEnd", "startDate": "2026-01-06", "endDate": "0001-01-01", "recurrenceTimeZone": "UTC", "numberOfOccurrences": 0}
+	}
+}`
+
+// seriesOccurrence returns an occurrence of seriesMaster
+func seriesOccurrence(id, eventType, start, originalStart string) string {
+	return `{
+		"id": "` + id + `",
+		"subject": "Team Sync",
+		"type": "` + eventType + `",
+		"seriesMasterId": "MASTER-1",
+		"originalStart": "` + originalStart + `",
+		"start": {"dateTime": "` + start + `", "timeZone": "UTC"},
+		"end": {"dateTime": "` + start[:11] + `23:00:00.0000000", "timeZone": "UTC"},
+		"organizer": {"emailAddress": {"name": "Alice", "address": "alice@example.com"}},
+		"responseStatus": {"response": "organizer"},
+		"attendees": [{"emailAddress": {"name": "Bob", "address": "bob@example.com"}, "type": "required"}],
+		"recurrence": null
+	}`
+}
+
+func TestGetCalendarView_Recurrence(t *testing.T) {
+	masterRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/me/calendarView":
+			w.Write([]byte(`{"value": [
+				` + seriesOccurrence("OCC-1", "occurrence", "2026-01-06T09:00:00.0000000", "2026-01-06T09:00:00Z") + `,
+				` + seriesOccurrence("EXC-1", "exception", "2026-01-08T14:00:00.0000000", "2026-01-08T09:00:00Z") + `,
+				{"id": "SINGLE-1", "subject": "Lunch", "type": "singleInstance",
+					"start": {"dateTime": "2026-01-07T12:00:00.0000000", "timeZone": "UTC"},
+					"end": {"dateTime": "2026-01-07T13:00:00.0000000", "timeZone": "UTC"},
+					"responseStatus": {"response": "organizer"},
+					"attendees": [{"emailAddress": {"name": "Bob", "address": "bob@example.com"}, "type": "required"}]}
+			]}`))
+		case "/me/events/MASTER-1":
+			masterRequests++
+			if r.URL.Query().Get("$select") != "recurrence" {
+				t.Errorf("Expected only the recurrence to be selected, got %q", r.URL.RawQuery)
+			}
+			w.Write([]byte(seriesMaster))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL)
+	start := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	events, err := client.GetCalendarView(context.Background(), start, start.Add(7*24*time.Hour), "UTC")
+	if err != nil {
+		t.Fatalf("GetCalendarView failed: %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 events, got %d", len(events))
+	}
+	if masterRequests != 1 {
+		t.Errorf("Expected the series master to be fetched once, got %d", masterRequests)
+	}
+
+	occurrence, single, exception := events[0], events[1], events[2]
+	if occurrence.Type != schema.EventTypeOccurrence || occurrence.SeriesMasterID != "MASTER-1" {
+		t.Errorf("Unexpected occurrence: %+v", occurrence)
+	}
+	if occurrence.Recurrence == nil || occurrence.Recurrence.Summary != "Weekly on Tue, Thu" {
+		t.Fatalf("Expected the series recurrence, got %+v", occurrence.Recurrence)
+	}
+	if occurrence.Recurrence.Range.EndDate != "" || occurrence.Recurrence.Pattern.Index != "" {
+		t.Errorf("Expected Graph defaults to be dropped, got %+v", occurrence.Recurrence)
+	}
+	if exception.Type != schema.EventTypeException || exception.Recurrence == nil {
+		t.Errorf("Unexpected exception: %+v", exception)
+	}
+	if exception.OriginalStart == nil || !exception.OriginalStart.Equal(time.Date(2026, 1, 8, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected original start 09:00, got %v", exception.OriginalStart)
+	}
+	if single.Type != schema.EventTypeSingleInstance || single.Recurrence != nil || single.OriginalStart != nil {
+		t.Errorf("Unexpected single instance: %+v", single)
+	}
+}
+
+func TestGetSeriesInstances(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/users/alice@example.com/events/EXC-1":
+			w.Write([]byte(seriesOccurrence("EXC-1", "exception", "2026-01-08T14:00:00.0000000", "2026-01-08T09:00:00Z")))
+		case "/users/alice@example.com/events/MASTER-1":
+			w.Write([]byte(seriesMaster))
+		case "/users/alice@example.com/events/MASTER-1/instances":
+			if r.URL.Query().Get("startDateTime") == "" || r.URL.Query().Get("endDateTime") == "" {
+				t.Errorf("Expected a window, got %q", r.URL.RawQuery)
+			}
+			if r.Header.Get("Prefer") != `outlook.timezone="UTC"` {
+				t.Errorf("Unexpected Prefer header %q", r.Header.Get("Prefer"))
+			}
+			w.Write([]byte(`{"value": [
+				` + seriesOccurrence("EXC-1", "exception", "2026-01-08T14:00:00.0000000", "2026-01-08T09:00:00Z") + `,
+				` + seriesOccurrence("OCC-1", "occurrence", "2026-01-06T09:00:00.0000000", "2026-01-06T09:00:00Z") + `
+			]}`))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL)
+	src := calendar.Source{User: "alice@example.com"}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	events, err := client.GetSeriesInstances(context.Background(), src, "EXC-1", start, start.AddDate(0, 1, 0), "UTC")
+	if err != nil {
+		t.Fatalf("GetSeriesInstances failed: %v", err)
+	}
+	if len(events) != 2 || events[0].ID != "OCC-1" || events[1].ID != "EXC-1" {
+		t.Fatalf("Expected occurrences in order, got %+v", events)
+	}
+	for _, event := range events {
+		if event.Recurrence == nil || event.Recurrence.Summary != "Weekly on Tue, Thu" {
+			t.Errorf("Expected the series recurrence on %s, got %+v", event.ID, event.Recurrence)
+		}
+		if event.Calendar == nil || event.Calendar.Owner != "alice@example.com" {
+			t.Errorf("Expected %s to be tagged with its mailbox, got %+v", event.ID, event.Calendar)
+		}
+	}
+}
+
+func TestGetSeriesInstances_Filter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/me/events/MASTER-1":
+			w.Write([]byte(seriesMaster))
+		case "/me/events/MASTER-1/instances":
+			w.Write([]byte(`{"value": [
+				` + seriesOccurrence("OCC-1", "occurrence", "2026-01-06T09:00:00.0000000", "2026-01-06T09:00:00Z") + `
+			]}`))
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL,
+		calendar.WithFilter(calendar.Filter{ResponseStatuses: []string{calendar.ResponseAccepted}}))
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	events, err := client.GetSeriesInstances(context.Background(), calendar.Source{}, "MASTER-1", start, start.AddDate(0, 1, 0), "UTC")
+	if err != nil {
+		t.Fatalf("GetSeriesInstances failed: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("Expected organized occurrences to be filtered out, got %+v", events)
+	}
+}
+
+func TestGetSeriesInstances_NotRecurring(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "SINGLE-1", "subject": "Lunch", "type": "singleInstance",
+			"start": {"dateTime": "2026-01-07T12:00:00.0000000", "timeZone": "UTC"},
+			"end": {"dateTime": "2026-01-07T13:00:00.0000000", "timeZone": "UTC"}}`))
+	}))
+	defer server.Close()
+
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := client.GetSeriesInstances(context.Background(), calendar.Source{}, "SINGLE-1", start, start.AddDate(0, 1, 0), "UTC"); err == nil {
+		t.Error("Expected an error for an event outside a series")
+	}
+}
+
+func TestGetCalendarView_RecurrenceUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/me/calendarView":
+			w.Write([]byte(`{"value": [
+				` + seriesOccurrence("OCC-1", "occurrence", "2026-01-06T09:00:00.0000000", "2026-01-06T09:00:00Z") + `,
+				` + seriesOccurrence("EXC-1", "exception", "2026-01-08T14:00:00.0000000", "2026-01-08T09:00:00Z") + `
+			]}`))
+		case "/me/events/MASTER-1":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	var warnings []error
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL,
+		calendar.WithWarnings(func(err error) { warnings = append(warnings, err) }))
+	start := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	events, err := client.GetCalendarView(context.Background(), start, start.Add(7*24*time.Hour), "UTC")
+	if err != nil {
+		t.Fatalf("Expected the agenda despite the failed series lookup, got %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	for _, event := range events {
+		if event.Recurrence != nil {
+			t.Errorf("Expected no recurrence on %s, got %+v", event.ID, event.Recurrence)
+		}
+	}
+	if len(warnings) != 1 {
+		t.Errorf("Expected one warning for the series, got %v", warnings)
+	}
+}
+
+func TestGetCalendarViewDelta_ReusesRecurrence(t *testing.T) {
+	masterRequests := 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/me/events/MASTER-1" {
+			masterRequests++
+			w.Write([]byte(seriesMaster))
+			return
+		}
+
+		occurrence := seriesOccurrence("OCC-1", "occurrence", "2026-01-06T09:00:00.0000000", "2026-01-06T09:00:00Z")
+		switch r.URL.Query().Get("$deltatoken") {
+		case "":
+			w.Write([]byte(`{"value": [` + occurrence + `],
+				"@odata.deltaLink": "` + server.URL + `/me/calendarView/delta?$deltatoken=round-1"}`))
+		case "round-1":
+			w.Write([]byte(`{"value": [],
+				"@odata.deltaLink": "` + server.URL + `/me/calendarView/delta?$deltatoken=round-2"}`))
+		default:
+			w.Write([]byte(`{"value": [` + occurrence + `],
+				"@odata.deltaLink": "` + server.URL + `/me/calendarView/delta?$deltatoken=round-3"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL)
+	start := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	end := start.Add(7 * 24 * time.Hour)
+
+	var state *calendar.DeltaState
+	for i, wantRequests := range []int{1, 1, 2} {
+		result, err := client.GetCalendarViewDelta(context.Background(), calendar.Source{}, start, end, "UTC", state)
+		if err != nil {
+			t.Fatalf("Sync %d failed: %v", i+1, err)
+		}
+		if len(result.Events) != 1 || result.Events[0].Recurrence == nil || result.Events[0].Recurrence.Summary != "Weekly on Tue, Thu" {
+			t.Fatalf("Sync %d: expected the occurrence with its recurrence, got %+v", i+1, result.Events)
+		}
+		if masterRequests != wantRequests {
+			t.Errorf("Sync %d: expected %d series master requests in total, got %d", i+1, wantRequests, masterRequests)
+		}
+		state = result.State
+	}
+}
@@ -0,0 +1,147 @@
+package calendar_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/obsidian-outlook-sync/outlook-md/internal/calendar"
+)
+
+// fakeTokens is a TokenSource that hands out "token-1", "token-2", ... on refresh
+type fakeTokens struct {
+	current    string
+	next       []string
+	refreshErr error
+	refreshes  int
+}
+
+func (f *fakeTokens) Token() (string, error) {
+	return f.current, nil
+}
+
+func (f *fakeTokens) Refresh() (string, error) {
+	f.refreshes++
+	if f.refreshErr != nil {
+		return "", f.refreshErr
+	}
+	f.current, f.next = f.next[0], f.next[1:]
+	return f.current, nil
+}
+
+// acceptingToken returns a server accepting only the given bearer token
+func acceptingToken(valid string, requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Authorization") != "Bearer "+valid {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error": {"code": "InvalidAuthenticationToken", "message": "Access token has expired or is not yet valid."}}`))
+			return
+		}
+		w.Write([]byte(`{"value": [` + deltaEvent("EVENT-1", "Standup", 9) + `]}`))
+	}))
+}
+
+func TestGetCalendarView_RefreshesRejectedToken(t *testing.T) {
+	requests := 0
+	server := acceptingToken("token-2", &requests)
+	defer server.Close()
+
+	tokens := &fakeTokens{current: "token-1", next: []string{"token-2"}}
+	client := calendar.NewGraphClientWithBaseURL(tokens, server.URL)
+
+	start := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	events, err := client.GetCalendarView(context.Background(), start, start.Add(24*time.Hour), "UTC")
+	if err != nil {
+		t.Fatalf("Expected success after refreshing the token, got %v", err)
+	}
+	if len(events) != 1 {
+		t.Errorf("Expected 1 event, got %d", len(events))
+	}
+	if tokens.refreshes != 1 || requests != 2 {
+		t.Errorf("Expected 1 refresh and 2 requests, got %d and %d", tokens.refreshes, requests)
+	}
+}
+
+func TestGetCalendarView_ReplaysOnlyOnce(t *testing.T) {
+	requests := 0
+	server := acceptingToken("never", &requests)
+	defer server.Close()
+
+	tokens := &fakeTokens{current: "token-1", next: []string{"token-2", "token-3"}}
+	client := calendar.NewGraphClientWithBaseURL(tokens, server.URL)
+
+	start := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	_, err := client.GetCalendarView(context.Background(), start, start.Add(24*time.Hour), "UTC")
+
+	var graphErr *calendar.GraphError
+	if !errors.As(err, &graphErr) || graphErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 Graph error, got %v", err)
+	}
+	if tokens.refreshes != 1 || requests != 2 {
+		t.Errorf("Expected 1 refresh and 2 requests, got %d and %d", tokens.refreshes, requests)
+	}
+}
+
+func TestGetCalendarView_RefreshFails(t *testing.T) {
+	requests := 0
+	server := acceptingToken("token-2", &requests)
+	defer server.Close()
+
+	tokens := &fakeTokens{current: "token-1", refreshErr: errors.New("refresh token revoked")}
+	client := calendar.NewGraphClientWithBaseURL(tokens, server.URL)
+
+	start := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	_, err := client.GetCalendarView(context.Background(), start, start.Add(24*time.Hour), "UTC")
+
+	var graphErr *calendar.GraphError
+	if !errors.As(err, &graphErr) || graphErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 Graph error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "refresh token revoked") {
+		t.Errorf("Expected refresh failure in error, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected no replay after failed refresh, got %d requests", requests)
+	}
+}
+
+func TestRevokeSignInSessions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/me/revokeSignInSessions" {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("Unexpected authorization: %s", r.Header.Get("Authorization"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"@odata.context": "https://graph.microsoft.com/v1.0/$metadata#Edm.Boolean", "value": true}`))
+	}))
+	defer server.Close()
+
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL)
+	if err := client.RevokeSignInSessions(context.Background()); err != nil {
+		t.Fatalf("RevokeSignInSessions failed: %v", err)
+	}
+}
+
+func TestRevokeSignInSessions_Forbidden(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error": {"code": "Authorization_RequestDenied", "message": "Insufficient privileges to complete the operation."}}`))
+	}))
+	defer server.Close()
+
+	client := calendar.NewGraphClientWithBaseURL(calendar.StaticToken("test-token"), server.URL)
+	err := client.RevokeSignInSessions(context.Background())
+	var graphErr *calendar.GraphError
+	if !errors.As(err, &graphErr) || graphErr.StatusCode != http.StatusForbidden || graphErr.Code != "Authorization_RequestDenied" {
+		t.Errorf("Expected 403 GraphError, got %v", err)
+	}
+}
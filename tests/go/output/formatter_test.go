@@ -92,3 +92,41 @@ func TestFormatJSON_EmptyEvents(t *testing.T) {
 		t.Errorf("Expected 0 events, got %d", len(parsed.Events))
 	}
 }
+
+func TestFormatCalendars(t *testing.T) {
+	list := &schema.CalendarList{
+		Version: 1,
+		Calendars: []schema.Calendar{
+			{ID: "CAL-1", Name: "Calendar", Color: "auto", Owner: schema.Organizer{Name: "Me", Email: "me@example.com"}, CanEdit: true, IsDefault: true},
+			{ID: "CAL-2", Name: "Team | Shared", Color: "#E07A5F", Owner: schema.Organizer{Email: "team@example.com"}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := output.FormatCalendars("json", list, &buf); err != nil {
+		t.Fatalf("FormatCalendars(json) failed: %v", err)
+	}
+	var decoded schema.CalendarList
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Failed to decode JSON: %v", err)
+	}
+	if decoded.Version != 1 || len(decoded.Calendars) != 2 || decoded.Calendars[1].Color != "#E07A5F" {
+		t.Errorf("Unexpected round-trip result: %+v", decoded)
+	}
+
+	buf.Reset()
+	if err := output.FormatCalendars("markdown", list, &buf); err != nil {
+		t.Fatalf("FormatCalendars(markdown) failed: %v", err)
+	}
+	expected := "| Name | Owner | Color | Can Edit | ID |\n" +
+		"| --- | --- | --- | --- | --- |\n" +
+		"| Calendar (default) | Me | auto | yes | CAL-1 |\n" +
+		"| Team \\| Shared | team@example.com | #E07A5F | no | CAL-2 |\n"
+	if buf.String() != expected {
+		t.Errorf("Unexpected markdown:\n%s\nwant:\n%s", buf.String(), expected)
+	}
+
+	if err := output.FormatCalendars("ics", list, &buf); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
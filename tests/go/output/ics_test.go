@@ -0,0 +1,224 @@
+package output_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/obsidian-outlook-sync/outlook-md/internal/output"
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+// unfoldICS reverses RFC 5545 line folding and splits into content lines
+func unfoldICS(t *testing.T, s string) []string {
+	t.Helper()
+	if !strings.HasSuffix(s, "\r\n") {
+		t.Fatalf("Expected output to end with CRLF")
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(s, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("Line exceeds 75 octets: %q", line)
+		}
+	}
+	s = strings.ReplaceAll(s, "\r\n ", "")
+	return strings.Split(strings.TrimSuffix(s, "\r\n"), "\r\n")
+}
+
+// containsLine reports whether lines contains want exactly
+func containsLine(lines []string, want string) bool {
+	for _, line := range lines {
+		if line == want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestFormatICS(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatalf("Failed to load timezone: %v", err)
+	}
+	start := time.Date(2026, 7, 1, 9, 0, 0, 0, loc)
+
+	cliOutput := &schema.CLIOutput{
+		Version:  1,
+		Timezone: "Europe/London",
+		Window: schema.TimeWindow{
+			Start: time.Date(2026, 7, 1, 0, 0, 0, 0, loc),
+			End:   time.Date(2026, 7, 2, 0, 0, 0, 0, loc),
+		},
+		Events: []schema.CalendarEvent{
+			{
+				ID:        "event-abc-123",
+				Subject:   "Planning; Q3, budget",
+				Start:     start,
+				End:       start.Add(30 * time.Minute),
+				Location:  "LON-Room 4",
+				Organizer: schema.Organizer{Name: "Smith, Alice", Email: "alice@example.com"},
+				Attendees: []schema.Attendee{
+					{Name: "Bob Jones", Email: "bob@example.com", Type: "required", Response: "accepted"},
+					{Name: "", Email: "carol@example.com", Type: "optional", Response: "notResponded"},
+					{Name: "LON-Room 4", Email: "room4@example.com", Type: "resource"},
+				},
+			},
+			{
+				ID:        "event-def-456",
+				Subject:   "Company holiday",
+				IsAllDay:  true,
+				Start:     time.Date(2026, 7, 1, 0, 0, 0, 0, loc),
+				End:       time.Date(2026, 7, 2, 0, 0, 0, 0, loc),
+				Organizer: schema.Organizer{Name: "HR", Email: "hr@example.com"},
+				Attendees: []schema.Attendee{},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := output.Format("ics", cliOutput, &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	lines := unfoldICS(t, buf.String())
+
+	if lines[0] != "BEGIN:VCALENDAR" || lines[len(lines)-1] != "END:VCALENDAR" {
+		t.Errorf("Expected VCALENDAR wrapper, got %q ... %q", lines[0], lines[len(lines)-1])
+	}
+
+	expected := []string{
+		"VERSION:2.0",
+		"BEGIN:VTIMEZONE",
+		"TZID:Europe/London",
+		// Spring forward and fall back around the window
+		"DTSTART:20260329T010000",
+		"TZOFFSETFROM:+0000",
+		"TZOFFSETTO:+0100",
+		"TZNAME:BST",
+		"DTSTART:20261025T020000",
+		"UID:event-abc-123",
+		"DTSTART;TZID=Europe/London:20260701T090000",
+		"DTEND;TZID=Europe/London:20260701T093000",
+		`SUMMARY:Planning\; Q3\, budget`,
+		"LOCATION:LON-Room 4",
+		`ORGANIZER;CN="Smith, Alice":mailto:alice@example.com`,
+		"ATTENDEE;CN=Bob Jones;ROLE=REQ-PARTICIPANT;CUTYPE=INDIVIDUAL;PARTSTAT=ACCEPTED:mailto:bob@example.com",
+		"ATTENDEE;ROLE=OPT-PARTICIPANT;CUTYPE=INDIVIDUAL;PARTSTAT=NEEDS-ACTION:mailto:carol@example.com",
+		"ATTENDEE;CN=LON-Room 4;ROLE=NON-PARTICIPANT;CUTYPE=RESOURCE:mailto:room4@example.com",
+		"UID:event-def-456",
+		"DTSTART;VALUE=DATE:20260701",
+		"DTEND;VALUE=DATE:20260702",
+	}
+	for _, want := range expected {
+		if !containsLine(lines, want) {
+			t.Errorf("Expected line %q in output:\n%s", want, strings.Join(lines, "\n"))
+		}
+	}
+
+	if n := strings.Count(buf.String(), "BEGIN:VEVENT"); n != 2 {
+		t.Errorf("Expected 2 VEVENTs, got %d", n)
+	}
+}
+
+func TestFormatICS_UTC(t *testing.T) {
+	start := time.Date(2026, 7, 1, 9, 0, 0, 0, time.UTC)
+	cliOutput := &schema.CLIOutput{
+		Version:  1,
+		Timezone: "UTC",
+		Window:   schema.TimeWindow{Start: start, End: start.Add(24 * time.Hour)},
+		Events: []schema.CalendarEvent{
+			{ID: "event-1", Subject: "Sync", Start: start, End: start.Add(time.Hour), Attendees: []schema.Attendee{}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := output.FormatICS(cliOutput, &buf); err != nil {
+		t.Fatalf("FormatICS failed: %v", err)
+	}
+	lines := unfoldICS(t, buf.String())
+
+	if containsLine(lines, "BEGIN:VTIMEZONE") {
+		t.Error("Expected no VTIMEZONE for UTC output")
+	}
+	for _, want := range []string{"DTSTART:20260701T090000Z", "DTEND:20260701T100000Z"} {
+		if !containsLine(lines, want) {
+			t.Errorf("Expected line %q", want)
+		}
+	}
+}
+
+func TestFormatICS_FoldsLongLines(t *testing.T) {
+	start := time.Date(2026, 7, 1, 9, 0, 0, 0, time.UTC)
+	subject := strings.Repeat("Quarterly café review ", 10)
+	cliOutput := &schema.CLIOutput{
+		Version:  1,
+		Timezone: "UTC",
+		Window:   schema.TimeWindow{Start: start, End: start.Add(24 * time.Hour)},
+		Events: []schema.CalendarEvent{
+			{ID: "event-1", Subject: subject, Start: start, End: start.Add(time.Hour), Attendees: []schema.Attendee{}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := output.FormatICS(cliOutput, &buf); err != nil {
+		t.Fatalf("FormatICS failed: %v", err)
+	}
+
+	// unfoldICS checks every physical line fits in 75 octets
+	lines := unfoldICS(t, buf.String())
+	if !containsLine(lines, "SUMMARY:"+subject) {
+		t.Errorf("Expected folded SUMMARY to unfold to the original subject")
+	}
+}
+
+func TestFormatICS_Metadata(t *testing.T) {
+	start := time.Date(2026, 7, 1, 9, 0, 0, 0, time.UTC)
+	created := time.Date(2026, 6, 20, 8, 15, 0, 0, time.UTC)
+	reminder := 15
+	cliOutput := &schema.CLIOutput{
+		Version:  1,
+		Timezone: "UTC",
+		Window:   schema.TimeWindow{Start: start, End: start.Add(24 * time.Hour)},
+		Events: []schema.CalendarEvent{
+			{
+				ID:                         "event-1",
+				Subject:                    "Review",
+				Start:                      start,
+				End:                        start.Add(time.Hour),
+				Attendees:                  []schema.Attendee{},
+				Categories:                 []string{"Red category", "Project, X"},
+				Importance:                 "high",
+				Sensitivity:                "private",
+				ShowAs:                     "free",
+				IsCancelled:                true,
+				ReminderMinutesBeforeStart: &reminder,
+				CreatedDateTime:            &created,
+				LastModifiedDateTime:       &created,
+				WebLink:                    "https://outlook.office365.com/owa/?itemid=AAMk1&exvsurl=1&path=/calendar/item",
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := output.FormatICS(cliOutput, &buf); err != nil {
+		t.Fatalf("FormatICS failed: %v", err)
+	}
+	lines := unfoldICS(t, buf.String())
+
+	for _, want := range []string{
+		"URL:https://outlook.office365.com/owa/?itemid=AAMk1&exvsurl=1&path=/calendar/item",
+		`CATEGORIES:Red category,Project\, X`,
+		"CLASS:PRIVATE",
+		"PRIORITY:1",
+		"STATUS:CANCELLED",
+		"TRANSP:TRANSPARENT",
+		"CREATED:20260620T081500Z",
+		"LAST-MODIFIED:20260620T081500Z",
+		"BEGIN:VALARM",
+		"TRIGGER:-PT15M",
+		"END:VALARM",
+	} {
+		if !containsLine(lines, want) {
+			t.Errorf("Expected line %q in output:\n%s", want, strings.Join(lines, "\n"))
+		}
+	}
+}
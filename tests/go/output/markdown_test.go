@@ -0,0 +1,167 @@
+package output_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/obsidian-outlook-sync/outlook-md/internal/output"
+	"github.com/obsidian-outlook-sync/outlook-md/pkg/schema"
+)
+
+func TestFormatMarkdown(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatalf("Failed to load timezone: %v", err)
+	}
+	start := time.Date(2026, 7, 1, 9, 0, 0, 0, loc)
+
+	cliOutput := &schema.CLIOutput{
+		Version:  1,
+		Timezone: "Europe/London",
+		Window: schema.TimeWindow{
+			Start: start,
+			End:   start.Add(24 * time.Hour),
+		},
+		Events: []schema.CalendarEvent{
+			{
+				ID:        "event-abc-123",
+				Subject:   "Team Standup",
+				Start:     start,
+				End:       start.Add(30 * time.Minute),
+				Organizer: schema.Organizer{Name: "Alice Smith", Email: "alice@example.com"},
+				Attendees: []schema.Attendee{
+					{Name: "Alice Smith", Email: "alice@example.com", Type: "required"},
+					{Name: "Bob Jones", Email: "bob@example.com", Type: "required"},
+					{Name: "", Email: "carol@example.com", Type: "optional"},
+					{Name: "LON-Room 4", Email: "room4@example.com", Type: "resource"},
+				},
+				Body: "**Goals**\n\n- Review roadmap\n  - Q3 numbers",
+			},
+			{
+				ID:        "event-def-456",
+				Subject:   "",
+				IsAllDay:  true,
+				Start:     time.Date(2026, 7, 1, 0, 0, 0, 0, loc),
+				End:       time.Date(2026, 7, 2, 0, 0, 0, 0, loc),
+				Organizer: schema.Organizer{Name: "", Email: "hr@example.com"},
+				Attendees: []schema.Attendee{},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := output.FormatMarkdown(cliOutput, &buf); err != nil {
+		t.Fatalf("FormatMarkdown failed: %v", err)
+	}
+
+	expected := strings.Join([]string{
+		"<!-- AGENDA_START -->",
+		"<!-- EVENT_ID: event-abc-123 -->",
+		"## 09:00-09:30 Team Standup",
+		"",
+		"### Agenda",
+		"- <auto> **Goals**",
+		"- <auto> Review roadmap",
+		"  - <auto> Q3 numbers",
+		"",
+		"### Attendees",
+		"Alice Smith (O), Bob Jones, carol@example.com",
+		"",
+		"### Notes",
+		"<!-- NOTES_START -->",
+		"",
+		"<!-- NOTES_END -->",
+		"",
+		"<!-- EVENT_ID: event-def-456 -->",
+		"## All Day - (Untitled Event)",
+		"",
+		"### Attendees",
+		"hr@example.com (O)",
+		"",
+		"### Notes",
+		"<!-- NOTES_START -->",
+		"",
+		"<!-- NOTES_END -->",
+		"",
+		"<!-- AGENDA_END -->",
+		"",
+	}, "\n")
+
+	if buf.String() != expected {
+		t.Errorf("Unexpected markdown output:\n--- got ---\n%s\n--- want ---\n%s", buf.String(), expected)
+	}
+}
+
+func TestFormatMarkdown_EmptyEvents(t *testing.T) {
+	now := time.Now()
+	cliOutput := &schema.CLIOutput{
+		Version:  1,
+		Timezone: "UTC",
+		Window:   schema.TimeWindow{Start: now, End: now.Add(24 * time.Hour)},
+		Events:   []schema.CalendarEvent{},
+	}
+
+	var buf bytes.Buffer
+	if err := output.FormatMarkdown(cliOutput, &buf); err != nil {
+		t.Fatalf("FormatMarkdown failed: %v", err)
+	}
+
+	expected := "<!-- AGENDA_START -->\n*No events for this time period*\n<!-- AGENDA_END -->\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestRenderEvent_TruncatesAttendees(t *testing.T) {
+	start := time.Date(2026, 1, 7, 14, 0, 0, 0, time.UTC)
+	event := schema.CalendarEvent{
+		ID:        "big-meeting",
+		Subject:   "Project Review",
+		Start:     start,
+		End:       start.Add(time.Hour),
+		Organizer: schema.Organizer{Name: "Project Manager", Email: "pm@example.com"},
+	}
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		event.Attendees = append(event.Attendees, schema.Attendee{Name: "User " + name, Email: name + "@example.com", Type: "required"})
+	}
+
+	lines := output.RenderEvent(event)
+	content := strings.Join(lines, "\n")
+
+	if !strings.Contains(content, "Project Manager (O), User a, User b, User c, User d, User e, …and 2 more") {
+		t.Errorf("Expected truncated attendee line, got:\n%s", content)
+	}
+}
+
+func TestRenderEvent_AccountLabel(t *testing.T) {
+	start := time.Date(2026, 1, 7, 9, 0, 0, 0, time.UTC)
+	event := schema.CalendarEvent{
+		ID:      "merged-event",
+		Subject: "Steering Committee",
+		Start:   start,
+		End:     start.Add(time.Hour),
+		Account: "contoso",
+	}
+
+	lines := output.RenderEvent(event)
+	if lines[1] != "## 09:00-10:00 [contoso] Steering Committee" {
+		t.Errorf("Expected account label in header, got %q", lines[1])
+	}
+}
+
+func TestFormat_UnsupportedFormat(t *testing.T) {
+	cliOutput := &schema.CLIOutput{Version: 1, Events: []schema.CalendarEvent{}}
+
+	var buf bytes.Buffer
+	if err := output.Format("xml", cliOutput, &buf); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+	if output.IsSupported("xml") {
+		t.Error("xml should not be supported")
+	}
+	if !output.IsSupported("markdown") {
+		t.Error("markdown should be supported")
+	}
+}
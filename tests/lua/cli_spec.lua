@@ -0,0 +1,73 @@
+-- Unit tests for cli.lua
+-- Tests parsing of CLI errors written with --error-format json
+
+local cli = require('obsidian_outlook_sync.cli')
+
+describe('cli', function()
+  describe('parse_cli_error', function()
+    it('should parse the JSON error line after other stderr output', function()
+      local output = table.concat({
+        'Warning: Graph API returned status 503: ; retrying in 1s',
+        '{"version":1,"error":{"kind":"throttled","exitCode":5,"message":"Graph API returned status 429 (TooManyRequests)",'
+          .. '"graph":{"status":429,"code":"TooManyRequests","retryAfter":30}}}',
+        '',
+      }, '\n')
+
+      local err = cli.parse_cli_error(output, 5)
+
+      assert.equals('throttled', err.kind)
+      assert.equals(5, err.exitCode)
+      assert.equals(429, err.graph.status)
+      assert.equals(30, err.graph.retryAfter)
+    end)
+
+    it('should derive the kind from the exit code without a JSON error', function()
+      local err = cli.parse_cli_error('Error: authentication failed\n', 3)
+
+      assert.equals('auth', err.kind)
+      assert.equals('Error: authentication failed', err.message)
+    end)
+
+    it('should fall back to a generic error for unknown exit codes', function()
+      local err = cli.parse_cli_error('panic: something broke', 42)
+
+      assert.equals('error', err.kind)
+    end)
+  end)
+
+  describe('build_command', function()
+    it('should leave the timezone, week start and responses to the profile when unset', function()
+      local cmd = cli.build_command('today', { cli_path = 'outlook-md', profile = 'work' })
+
+      assert.falsy(cmd:match('%-%-tz'))
+      assert.falsy(cmd:match('%-%-week%-start'))
+      assert.falsy(cmd:match('%-%-responses'))
+      assert.truthy(cmd:match("%-%-profile 'work'"))
+    end)
+
+    it('should pass the timezone, week start and responses when set', function()
+      local cmd = cli.build_command('week', {
+        timezone = 'Europe/London',
+        week_start = 'sunday',
+        response_statuses = { 'accepted', 'tentativelyAccepted' },
+      })
+
+      assert.truthy(cmd:match("%-%-tz 'Europe/London'"))
+      assert.truthy(cmd:match("%-%-week%-start 'sunday'"))
+      assert.truthy(cmd:match("%-%-responses 'accepted,tentativelyAccepted'"))
+    end)
+  end)
+
+  describe('format_error', function()
+    it('should add a hint for the error kind', function()
+      local msg = cli.format_error({ kind = 'permission', message = 'Access is denied.' })
+
+      assert.truthy(msg:match('^Access is denied%.'))
+      assert.truthy(msg:match('shared with you'))
+    end)
+
+    it('should leave generic errors without a hint', function()
+      assert.equals('boom', cli.format_error({ kind = 'error', message = 'boom' }))
+    end)
+  end)
+end)
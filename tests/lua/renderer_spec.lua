@@ -103,6 +103,23 @@ describe('renderer', function()
       assert.matches('%(Untitled Event%)', header)
     end)
 
+    it('should label events merged from several accounts', function()
+      local event = {
+        id = 'test-account',
+        subject = 'Steering Committee',
+        isAllDay = false,
+        start = '2026-01-07T09:00:00',
+        ['end'] = '2026-01-07T10:00:00',
+        organizer = { name = '', email = '' },
+        attendees = {},
+        account = 'contoso'
+      }
+
+      local lines = renderer.render_event(event)
+
+      assert.equals('## 09:00-10:00 [contoso] Steering Committee', lines[2])
+    end)
+
     it('should render multiple attendees', function()
       local event = {
         id = 'test-attendees',
@@ -327,5 +344,29 @@ describe('renderer', function()
       -- Should show "and 85 more" (100 - 15 = 85)
       assert.matches('and 85 more', content)
     end)
+
+    it('should render body lines as agenda items', function()
+      local event = {
+        id = 'test-body',
+        subject = 'Planning',
+        isAllDay = false,
+        start = '2026-01-07T10:00:00',
+        ['end'] = '2026-01-07T11:00:00',
+        location = '',
+        organizer = { name = 'Organizer', email = 'org@example.com' },
+        attendees = {},
+        body = '**Goals**\n\n- Review [roadmap](https://example.com)\n  - Q3\n1. Decide'
+      }
+
+      local lines = renderer.render_event(event)
+
+      assert.are.equal('### Agenda', lines[4])
+      assert.are.equal('- <auto> **Goals**', lines[5])
+      assert.are.equal('- <auto> Review [roadmap](https://example.com)', lines[6])
+      assert.are.equal('  - <auto> Q3', lines[7])
+      assert.are.equal('- <auto> 1. Decide', lines[8])
+      assert.are.equal('', lines[9])
+      assert.are.equal('### Attendees', lines[10])
+    end)
   end)
 end)